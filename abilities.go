@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"strings"
+	"time"
+)
+
+const abilityMeterWidth = 5
+const dashDistance = 6 // Number of cells the ship moves when dashing
+
+// Ability the player activates with its own key, which lasts a while then has to recharge
+type ability struct {
+	duration    time.Duration // How long the ability lasts once activated
+	cooldown    time.Duration // Time from activation until it can be used again
+	activatedAt time.Duration // Time on the game clock
+	used        bool
+}
+
+var shieldAbility = ability{
+	duration: 1500 * time.Millisecond,
+	cooldown: 8 * time.Second,
+}
+
+var dashAbility = ability{
+	duration: 300 * time.Millisecond, // The ship is invulnerable for this long after dashing
+	cooldown: 4 * time.Second,
+}
+
+func (a *ability) isReady(now time.Duration) bool {
+	return !a.used || now-a.activatedAt >= a.cooldown
+}
+
+func (a *ability) isActive(now time.Duration) bool {
+	return a.used && now-a.activatedAt < a.duration
+}
+
+// Activate the ability if it's ready, returning whether it was
+func (a *ability) activate(now time.Duration) bool {
+	if !a.isReady(now) {
+		return false
+	}
+	a.used = true
+	a.activatedAt = now
+	return true
+}
+
+// How far the ability has recharged, from 0 to 1
+func (a *ability) charge(now time.Duration) float64 {
+	if !a.used {
+		return 1
+	}
+	return min(float64(now-a.activatedAt)/float64(a.cooldown), 1)
+}
+
+func (a *ability) getMeterString(name string, now time.Duration) string {
+	if a.isActive(now) {
+		return fmt.Sprintf("%s: [%s]", name, extraLifeStyle.Render(fmt.Sprintf("%-*s", abilityMeterWidth, "ON")))
+	}
+	filledCount := int(a.charge(now) * abilityMeterWidth)
+	return fmt.Sprintf("%s: [%s%s]", name, strings.Repeat("#", filledCount), strings.Repeat(" ", abilityMeterWidth-filledCount))
+}
+
+func (gv *gameView) activateShield() {
+	gv.shield.activate(gv.clock.now())
+}
+
+func (gv *gameView) isShieldActive() bool {
+	return gv.shield.isActive(gv.clock.now())
+}
+
+// Move the ship several cells in the direction it last moved, stopping at walls, and make it briefly invulnerable
+func (gv *gameView) dashPlayer() tea.Cmd {
+	if !gv.dash.activate(gv.clock.now()) {
+		return nil
+	}
+	gv.lives.extendInvulnerability(gv.clock.now(), gv.dash.duration)
+
+	for i := 0; i < dashDistance; i++ {
+		nextPosition := vector2d{x: gv.playerPosition.x + gv.lastMoveDirection.x, y: gv.playerPosition.y}
+		if !isPositionValid(nextPosition) || (gv.stage == scrollingStage && gv.isShipInWall(nextPosition)) {
+			break
+		}
+		gv.playerPosition = nextPosition
+	}
+	return gv.handlePlayerMoved()
+}
+
+// Draw the shield either side of the ship while it's active
+func (gv *gameView) drawShield(outputMatrix *[][]cell) {
+	if !gv.isShieldActive() {
+		return
+	}
+	offset := gv.stats.hitboxWidth/2 + 1
+	for dx, r := range map[int]rune{-offset: '(', offset: ')'} {
+		position := vector2d{x: gv.playerPosition.x + dx, y: gv.playerPosition.y}
+		if isPositionValid(position) {
+			(*outputMatrix)[position.y][position.x] = cell{r: r, colour: accentColor}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import "time"
+
+const startingSmartBombs = 2
+const maxSmartBombs = 5
+const smartBombScorePercent = 50 // Kills made by a smart bomb are worth less than shooting enemies
+const smartBombBossDamage = 5
+const smartBombFlashDuration = 200 * time.Millisecond
+const bombPickupDropChancePercent = 3 // Chance of a destroyed enemy dropping a bomb pickup
+const pickupMoveInterval = 3          // Pickups fall once every this many bullet ticks
+
+// Clear all enemy bullets and damage every enemy on screen
+func (gv *gameView) detonateSmartBomb() {
+	if gv.smartBombs <= 0 {
+		return
+	}
+	gv.smartBombs--
+	gv.screenFlashUntil = gv.clock.now() + smartBombFlashDuration
+
+	// Scores are reduced for everything destroyed by the bomb, including any chain reaction it sets off
+	gv.detonatingSmartBomb = true
+	defer func() { gv.detonatingSmartBomb = false }()
+
+	for _, position := range gv.enemyPositions.toSlice() {
+		gv.damageEnemy(position, 1)
+	}
+
+	gv.addScore(scorePerDiverHit * len(gv.divers))
+	gv.divers = nil
+
+	for i := range gv.challengeEnemies {
+		enemy := &gv.challengeEnemies[i]
+		if _, present := enemy.position(gv.challengeTick); present && !enemy.destroyed {
+			enemy.destroyed = true
+			gv.challengeHitCount++
+			gv.addScore(scorePerChallengeEnemyHit)
+		}
+	}
+
+	if gv.boss != nil {
+		gv.damageBoss(smartBombBossDamage)
+	}
+
+	// Cleared last, as destroying some enemies releases bullets
+	gv.enemyBullets = make([]enemyBullet, 0)
+	gv.checkWaveCleared()
+}
+
+func (gv *gameView) isScreenFlashing() bool {
+	return gv.clock.now() < gv.screenFlashUntil
+}
+
+func (gv *gameView) dropBombPickup(position vector2d) {
+	if gv.rng.IntN(100) < bombPickupDropChancePercent {
+		gv.bombPickups = append(gv.bombPickups, position)
+	}
+}
+
+// Move bomb and drone pickups down the screen, removing them once they leave the bottom
+func (gv *gameView) updatePickups() {
+	gv.pickupTickCount++
+	if gv.pickupTickCount < pickupMoveInterval {
+		return
+	}
+	gv.pickupTickCount = 0
+
+	gv.bombPickups = movePickupsDown(gv.bombPickups)
+	gv.dronePickups = movePickupsDown(gv.dronePickups)
+}
+
+func movePickupsDown(pickups []vector2d) []vector2d {
+	updatedPickups := make([]vector2d, 0, len(pickups))
+	for _, position := range pickups {
+		position.y++
+		if isPositionValid(position) {
+			updatedPickups = append(updatedPickups, position)
+		}
+	}
+	return updatedPickups
+}
+
+// The player collects bomb pickups by touching them, up to the maximum number of bombs
+func (gv *gameView) handleBombPickupCollisions() {
+	updatedPickups := make([]vector2d, 0, len(gv.bombPickups))
+	for _, position := range gv.bombPickups {
+		if gv.isPlayerAt(position) {
+			gv.smartBombs = min(gv.smartBombs+1, maxSmartBombs)
+		} else {
+			updatedPickups = append(updatedPickups, position)
+		}
+	}
+	gv.bombPickups = updatedPickups
+}
+
+func (gv *gameView) drawBombPickups(outputMatrix *[][]cell) {
+	for _, position := range gv.bombPickups {
+		(*outputMatrix)[position.y][position.x] = cell{r: 'B', colour: accentColor}
+	}
+}
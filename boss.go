@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const bossMaxHP = 40
+const bossFireChancePercent = 60
+const scorePerBossHit = 10
+const scorePerBossDefeated = 5000
+
+var bossSprite = []rune("<=[###]=>")
+
+// Boss at the end of the scrolling stage or a campaign stage; moves from side to side near the top of the screen
+type boss struct {
+	position  vector2d // Position of the left end of the sprite
+	direction int
+	hp        int
+}
+
+func (gv *gameView) spawnBoss() {
+	gv.boss = &boss{
+		position:  vector2d{x: (gameViewSize.x - len(bossSprite)) / 2, y: 1},
+		direction: 1,
+		hp:        bossMaxHP,
+	}
+}
+
+func (gv *gameView) updateBoss() {
+	b := gv.boss
+	if b.position.x+b.direction < 0 || b.position.x+b.direction+len(bossSprite) > gameViewSize.x {
+		b.direction = -b.direction
+	}
+	b.position.x += b.direction
+
+	if gv.rng.IntN(100) < bossFireChancePercent {
+		gv.enemyBullets = append(gv.enemyBullets, newEnemyBullet(plainBullet, vector2d{x: b.position.x + gv.rng.IntN(len(bossSprite)), y: b.position.y + 1}))
+	}
+}
+
+// Handle collisions between player bullets and the boss
+// Remove the player bullet and decrement the boss's HP, winning the game once it reaches zero
+func (gv *gameView) handleBossCollisions() {
+	b := gv.boss
+	if b == nil {
+		return
+	}
+
+	updatedBulletPositions := make([]vector2d, 0, len(gv.playerBullets))
+	for _, bulletPosition := range gv.playerBullets {
+		if bulletPosition.y == b.position.y && bulletPosition.x >= b.position.x && bulletPosition.x < b.position.x+len(bossSprite) && b.hp > 0 {
+			gv.damageBoss(1)
+		} else {
+			updatedBulletPositions = append(updatedBulletPositions, bulletPosition)
+		}
+	}
+	gv.playerBullets = updatedBulletPositions
+}
+
+func (gv *gameView) damageBoss(damage int) {
+	b := gv.boss
+	damage = min(damage, b.hp)
+	b.hp -= damage
+	gv.addScore(scorePerBossHit * damage)
+	if b.hp <= 0 {
+		gv.addScore(scorePerBossDefeated)
+		gv.endGame(gameWon)
+	}
+}
+
+func (gv *gameView) getBossHUDString() string {
+	filledCount := (gv.boss.hp*10 + bossMaxHP - 1) / bossMaxHP
+	return fmt.Sprintf("Boss: [%s%s]", strings.Repeat("#", filledCount), strings.Repeat(" ", 10-filledCount))
+}
+
+func (gv *gameView) drawBoss(outputMatrix *[][]cell) {
+	if b := gv.boss; b != nil {
+		for i, r := range bossSprite {
+			(*outputMatrix)[b.position.y][b.position.x+i] = cell{r: r}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const campaignProgressFileName = "campaign_progress.json"
+
+//go:embed campaign.json
+var campaignStagesJSON []byte
+
+type campaignStage struct {
+	Name                   string `json:"name"`
+	Intro                  string `json:"intro"`
+	RowCount               int    `json:"rowCount"`
+	ColumnCount            int    `json:"columnCount"`
+	EnemyTickIntervalMs    int    `json:"enemyTickIntervalMs"`
+	EnemyFireChancePercent int    `json:"enemyFireChancePercent"`
+	Boss                   bool   `json:"boss"` // Whether a boss appears once the formation has been destroyed
+}
+
+type campaignProgress struct {
+	UnlockedStageCount int `json:"unlockedStageCount"`
+}
+
+func loadCampaignStages() ([]campaignStage, error) {
+	var stages []campaignStage
+	if err := json.Unmarshal(campaignStagesJSON, &stages); err != nil {
+		return nil, err
+	}
+
+	for i, stage := range stages {
+		// Enemies are spaced out with a gap between each, so they need to fit within the screen width
+		if stage.RowCount < 1 || stage.ColumnCount < 1 || (stage.ColumnCount*(enemySpacing+1))-enemySpacing > gameViewSize.x {
+			return nil, fmt.Errorf("stage %d (%s) has an invalid formation size", i+1, stage.Name)
+		}
+	}
+	return stages, nil
+}
+
+func loadCampaignProgress() (campaignProgress, error) {
+	progress := campaignProgress{UnlockedStageCount: 1}
+	err := readDataFile(campaignProgressFileName, &progress)
+	return progress, err
+}
+
+// Unlock the stage after the given stage, if it isn't already unlocked
+func unlockNextCampaignStage(stageIndex int) error {
+	progress, err := loadCampaignProgress()
+	if err != nil {
+		return err
+	}
+
+	if progress.UnlockedStageCount > stageIndex+1 {
+		return nil
+	}
+	progress.UnlockedStageCount = stageIndex + 2
+	return writeDataFile(campaignProgressFileName, progress)
+}
+
+func (cs campaignStage) enemyTickInterval() time.Duration {
+	return time.Duration(cs.EnemyTickIntervalMs) * time.Millisecond
+}
+
+func (gv *gameView) campaignStage() campaignStage {
+	return gv.config.campaignStages[gv.config.campaignStageIndex]
+}
+
+func (gv *gameView) hasNextCampaignStage() bool {
+	return gv.config.campaignStages != nil && gv.config.campaignStageIndex+1 < len(gv.config.campaignStages)
+}
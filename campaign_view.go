@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"retro-shooter-game/key_maps"
+	"strings"
+)
+
+// Stage select for the campaign; only unlocked stages can be selected
+type campaignSelectView struct {
+	stages             []campaignStage
+	unlockedStageCount int
+	selectedStageIndex int
+	err                error
+}
+
+func newCampaignSelectView() campaignSelectView {
+	stages, err := loadCampaignStages()
+	if err != nil {
+		return campaignSelectView{err: err}
+	}
+
+	progress, err := loadCampaignProgress()
+	if err != nil {
+		return campaignSelectView{err: err}
+	}
+
+	unlockedStageCount := min(progress.UnlockedStageCount, len(stages))
+	return campaignSelectView{
+		stages:             stages,
+		unlockedStageCount: unlockedStageCount,
+		selectedStageIndex: unlockedStageCount - 1, // Start on the furthest stage reached
+	}
+}
+
+func (csv campaignSelectView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key_maps.CampaignSelectKeys.Up) && csv.err == nil:
+			csv.selectedStageIndex = (csv.selectedStageIndex - 1 + csv.unlockedStageCount) % csv.unlockedStageCount
+			m.view = csv
+		case key.Matches(msg, key_maps.CampaignSelectKeys.Down) && csv.err == nil:
+			csv.selectedStageIndex = (csv.selectedStageIndex + 1) % csv.unlockedStageCount
+			m.view = csv
+		case key.Matches(msg, key_maps.CampaignSelectKeys.Start) && csv.err == nil:
+			config := m.config
+			config.campaignStages = csv.stages
+			config.campaignStageIndex = csv.selectedStageIndex
+			m.view = stageIntroView{config: config}
+		case key.Matches(msg, key_maps.CampaignSelectKeys.Back):
+			m.view = newTitleView()
+		}
+	}
+	return m, nil
+}
+
+func (csv campaignSelectView) draw(m model) string {
+	if csv.err != nil {
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().PaddingBottom(1).Render(fmt.Sprintf("Couldn't load campaign: %v", csv.err)),
+			m.help.View(key_maps.CampaignSelectKeys),
+		)
+	}
+
+	var sb strings.Builder
+	for i, stage := range csv.stages {
+		locked := i >= csv.unlockedStageCount
+		line := fmt.Sprintf("Stage %d: %s", i+1, stage.Name)
+		if locked {
+			line = fmt.Sprintf("Stage %d: ???", i+1)
+		}
+		sb.WriteString(drawMenuItem(line, i == csv.selectedStageIndex && !locked, locked))
+		sb.WriteString("\n")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().PaddingBottom(1).Render("Campaign"),
+		lipgloss.NewStyle().PaddingBottom(1).Render(sb.String()),
+		m.help.View(key_maps.CampaignSelectKeys),
+	)
+}
+
+// Intro text screen shown before each campaign stage
+type stageIntroView struct {
+	config gameConfig
+}
+
+func (siv stageIntroView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key_maps.StageIntroKeys.Start):
+			m.view = newShipSelectView(siv.config)
+		case key.Matches(msg, key_maps.StageIntroKeys.Back):
+			m.view = newCampaignSelectView()
+		}
+	}
+	return m, nil
+}
+
+func (siv stageIntroView) draw(m model) string {
+	stage := siv.config.campaignStages[siv.config.campaignStageIndex]
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(accentColor).Bold(true).PaddingBottom(1).Render(fmt.Sprintf("Stage %d: %s", siv.config.campaignStageIndex+1, stage.Name)),
+		lipgloss.NewStyle().PaddingBottom(1).Render(stage.Intro),
+		m.help.View(key_maps.StageIntroKeys),
+	)
+}
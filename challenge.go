@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const challengeStageInterval = 3 // Every this many waves is a challenge stage, in modes with multiple waves
+const challengeEnemyTickInterval = 120 * time.Millisecond
+const scorePerChallengeEnemyHit = 100
+const challengePerfectBonus = 5000
+
+type stageType int
+
+const (
+	formationStage stageType = iota
+	challengeStage           // Enemies fly through along scripted paths without shooting
+	scrollingStage           // The screen scrolls through a level, ending with a boss
+)
+
+// A group of enemies following the same path, one after another
+type challengeGroup struct {
+	waypoints    []vector2d
+	enemyCount   int
+	startTick    int
+	spacingTicks int // Number of ticks between each enemy in the group entering
+}
+
+type challengeEnemy struct {
+	path      []vector2d // Position of the enemy on each tick after it enters
+	startTick int
+	destroyed bool
+}
+
+var challengeGroups = []challengeGroup{
+	// Swoop in from the top left and out to the right
+	{waypoints: []vector2d{{0, 0}, {20, 8}, {49, 2}}, enemyCount: 5, startTick: 0, spacingTicks: 2},
+	// Same again, mirrored
+	{waypoints: []vector2d{{49, 0}, {29, 8}, {0, 2}}, enemyCount: 5, startTick: 30, spacingTicks: 2},
+	// Loop down towards the player and back up
+	{waypoints: []vector2d{{10, 0}, {10, 9}, {25, 11}, {40, 9}, {40, 0}}, enemyCount: 6, startTick: 60, spacingTicks: 2},
+	// Zig-zag across the screen
+	{waypoints: []vector2d{{0, 5}, {12, 2}, {24, 7}, {36, 2}, {49, 5}}, enemyCount: 6, startTick: 100, spacingTicks: 2},
+}
+
+func isChallengeWave(wave int) bool {
+	return wave%challengeStageInterval == 0
+}
+
+func generateChallengeEnemies() (enemies []challengeEnemy) {
+	for _, group := range challengeGroups {
+		path := rasterizePath(group.waypoints)
+		for i := 0; i < group.enemyCount; i++ {
+			enemies = append(enemies, challengeEnemy{
+				path:      path,
+				startTick: group.startTick + (i * group.spacingTicks),
+			})
+		}
+	}
+	return
+}
+
+// Convert a list of waypoints into the position on each tick, moving at most one cell each way per tick
+func rasterizePath(waypoints []vector2d) []vector2d {
+	path := []vector2d{waypoints[0]}
+	for i := 1; i < len(waypoints); i++ {
+		from, to := waypoints[i-1], waypoints[i]
+		stepCount := max(abs(to.x-from.x), abs(to.y-from.y))
+		for step := 1; step <= stepCount; step++ {
+			path = append(path, vector2d{
+				x: from.x + roundedDivide((to.x-from.x)*step, stepCount),
+				y: from.y + roundedDivide((to.y-from.y)*step, stepCount),
+			})
+		}
+	}
+	return path
+}
+
+func roundedDivide(a, b int) int {
+	if a < 0 {
+		return -roundedDivide(-a, b)
+	}
+	return (a + (b / 2)) / b
+}
+
+// Returns false if the enemy hasn't entered yet, has already left the screen, or has been destroyed
+func (ce challengeEnemy) position(tick int) (vector2d, bool) {
+	pathIndex := tick - ce.startTick
+	if ce.destroyed || pathIndex < 0 || pathIndex >= len(ce.path) {
+		return emptyVector2d, false
+	}
+	return ce.path[pathIndex], true
+}
+
+func (ce challengeEnemy) hasLeft(tick int) bool {
+	return tick-ce.startTick >= len(ce.path)
+}
+
+func (gv *gameView) updateChallengeEnemies() {
+	gv.challengeTick++
+
+	for _, enemy := range gv.challengeEnemies {
+		if !enemy.destroyed && !enemy.hasLeft(gv.challengeTick) {
+			return
+		}
+	}
+
+	// Stage is over once every enemy has been destroyed or has left the screen
+	if gv.challengeHitCount == len(gv.challengeEnemies) {
+		gv.addScore(challengePerfectBonus)
+	}
+	gv.handleWaveCleared()
+}
+
+// Handle collisions between challenge stage enemies and player bullets or the player itself
+// Enemies hitting the player cost a life, as with formation enemies
+func (gv *gameView) handleChallengeEnemyCollisions() {
+	for i := range gv.challengeEnemies {
+		enemy := &gv.challengeEnemies[i]
+		position, present := enemy.position(gv.challengeTick)
+		if !present {
+			continue
+		}
+
+		updatedBulletPositions := make([]vector2d, 0, len(gv.playerBullets))
+		for _, bulletPosition := range gv.playerBullets {
+			if bulletPosition == position && !enemy.destroyed {
+				enemy.destroyed = true
+				gv.challengeHitCount++
+				gv.addScore(scorePerChallengeEnemyHit)
+			} else {
+				updatedBulletPositions = append(updatedBulletPositions, bulletPosition)
+			}
+		}
+		gv.playerBullets = updatedBulletPositions
+
+		if gv.isDroneAt(position) && !enemy.destroyed {
+			enemy.destroyed = true
+			gv.destroyDrone()
+		}
+		if gv.isPlayerAt(position) && !enemy.destroyed && gv.isPlayerVulnerable() {
+			enemy.destroyed = true
+			gv.loseLife()
+		}
+	}
+}
+
+func (gv *gameView) getChallengeResultString() string {
+	resultString := fmt.Sprintf("Challenge stage complete! Hits: %d/%d", gv.challengeHitCount, len(gv.challengeEnemies))
+	if gv.challengeHitCount == len(gv.challengeEnemies) {
+		resultString += fmt.Sprintf(" — Perfect! +%d", challengePerfectBonus)
+	}
+	return resultString
+}
+
+func (gv *gameView) drawChallengeEnemies(outputMatrix *[][]cell) {
+	for _, enemy := range gv.challengeEnemies {
+		if position, present := enemy.position(gv.challengeTick); present {
+			(*outputMatrix)[position.y][position.x] = cell{r: '&'}
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "time"
+
+// Measures time elapsed in a game, excluding any time spent paused
+// Used for timers stored in the game state, so they don't run out while the game is paused
+type gameClock struct {
+	startTime      time.Time
+	pauseTime      time.Time // Zero if not paused
+	pausedDuration time.Duration
+}
+
+func newGameClock() gameClock {
+	return gameClock{startTime: time.Now()}
+}
+
+func (c gameClock) now() time.Duration {
+	if !c.pauseTime.IsZero() {
+		return c.pauseTime.Sub(c.startTime) - c.pausedDuration
+	}
+	return time.Now().Sub(c.startTime) - c.pausedDuration
+}
+
+func (c *gameClock) pause() {
+	if c.pauseTime.IsZero() {
+		c.pauseTime = time.Now()
+	}
+}
+
+func (c *gameClock) resume() {
+	if !c.pauseTime.IsZero() {
+		c.pausedDuration += time.Now().Sub(c.pauseTime)
+		c.pauseTime = time.Time{}
+	}
+}
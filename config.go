@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+const defaultPlayerMoveStep = 1
+const defaultRespawnInvulnerabilityDuration = 2 * time.Second
+
+// Options for a game, chosen before it starts
+type gameConfig struct {
+	mode           gameMode
+	seed           uint64 // Seed for the game's RNG
+	dailyChallenge bool
+	// Set once a daily challenge is prepared
+	dailyChallengeDate   string
+	dailyChallengeScored bool // Whether this is the day's scored attempt, rather than a practice run
+	// Set for campaign stages
+	campaignStages     []campaignStage
+	campaignStageIndex int
+	freeMovement       bool // Whether the player can also move up and down within the player zone
+	playerMoveStep     int  // Number of cells the player moves left/right per key press
+	// How long the player can't be hit for after respawning
+	respawnInvulnerabilityDuration time.Duration
+	recentreOnRespawn              bool // Whether the player is moved back to the centre when respawning
+	landingRule                    landingRule
+	modifiers                      modifierSet
+	shieldRam                      bool // Whether the player starts with ram shield charges, for destroying enemies by contact
+	difficulty                     difficulty
+	credits                        int  // Number of continues available after losing
+	kiosk                          bool // Whether the game is running unattended on a shared terminal
+	demo                           bool // Set for the demo game in kiosk mode's attract loop, which the computer plays
+	ship                           shipType
+}
+
+// Register command-line flags for the game config; the returned config is filled in once `flag.Parse` is called
+func registerFlags() *gameConfig {
+	var config gameConfig
+	flag.BoolVar(&config.freeMovement, "free-movement", false, "allow the ship to move up and down as well as left and right")
+	flag.IntVar(&config.playerMoveStep, "move-step", defaultPlayerMoveStep, "number of cells the ship moves left/right per key press")
+	flag.DurationVar(&config.respawnInvulnerabilityDuration, "invulnerability", defaultRespawnInvulnerabilityDuration, "how long the ship can't be hit for after respawning")
+	flag.BoolVar(&config.recentreOnRespawn, "recentre", false, "move the ship back to the centre when respawning")
+	flag.Var(&config.landingRule, "landing", "what happens when the enemies reach the bottom (classic, push-back or invasion; defaults to the game mode's rule)")
+	flag.BoolVar(&config.shieldRam, "shield-ram", false, "give the ship a shield that destroys enemies it touches (for a limited number of enemies)")
+	flag.IntVar(&config.credits, "credits", defaultCredits, "number of continues available after losing (continuing resets the score)")
+	flag.BoolVar(&config.kiosk, "kiosk", false, "run unattended on a shared terminal, with an attract loop when idle and no quit key (ctrl+q quits)")
+	flag.Var(&config.difficulty, "difficulty", "how quickly the enemy formation speeds up as it shrinks and descends (easy, normal or hard)")
+	return &config
+}
+
+func (config gameConfig) validate() error {
+	if config.playerMoveStep < 1 {
+		return errors.New("move step must be at least 1")
+	}
+	if config.credits < 0 {
+		return errors.New("credits can't be negative")
+	}
+	if config.respawnInvulnerabilityDuration < 0 {
+		return errors.New("invulnerability duration can't be negative")
+	}
+	return nil
+}
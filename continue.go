@@ -0,0 +1,55 @@
+package main
+
+import "fmt"
+
+const continueCountdownSeconds = 10
+const defaultCredits = 3
+
+// Lose the game, unless the player has credits left, in which case they're offered a continue first
+func (gv *gameView) loseGame() {
+	if gv.creditsRemaining <= 0 {
+		gv.endGame(gameLost)
+		return
+	}
+
+	gv.status = continueCountdown
+	gv.continueSecondsRemaining = continueCountdownSeconds
+	gv.clock.pause()
+}
+
+// Count down the continue offer by a second, losing the game once it runs out
+func (gv *gameView) updateContinueCountdown() {
+	gv.continueSecondsRemaining--
+	if gv.continueSecondsRemaining <= 0 {
+		gv.endGame(gameLost)
+	}
+}
+
+// Use a credit to carry on from the current wave, with the score reset to zero as on an arcade machine
+func (gv *gameView) continueGame() {
+	gv.creditsRemaining--
+	gv.continueCount++
+	gv.score = 0
+	gv.lives = newLives(gv.lives.settings)
+	gv.smartBombs = max(gv.smartBombs, startingSmartBombs)
+	gv.clock.resume()
+
+	// Restart the wave the game was lost on; the scrolling stage carries on from where the ship was lost instead
+	if gv.stage != scrollingStage {
+		gv.boss = nil
+		gv.invasionCount = 0
+		gv.wave--
+		gv.startNextWave()
+	}
+	gv.respawn()
+	gv.status = playing
+}
+
+// Number of credits used, counting the first one; one-credit clears ("1CC") are marked on the leaderboard
+func (gv *gameView) creditsUsed() int {
+	return gv.continueCount + 1
+}
+
+func (gv *gameView) getContinueString() string {
+	return fmt.Sprintf("Continue? %d\nCredits: %d (score resets to 0)", gv.continueSecondsRemaining, gv.creditsRemaining)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+const dailyChallengeFileName = "daily_challenge.json"
+
+// Dates (in `time.DateOnly` format) of daily challenges that have already had their scored attempt
+type dailyChallengeAttempts struct {
+	Dates []string `json:"dates"`
+}
+
+func dailyChallengeLeaderboardCategory(date string) string {
+	return "daily-" + date
+}
+
+// Derive the config for the given day's challenge, so everyone gets the same run on the same day
+// Any options set from the command line are overridden
+func dailyChallengeConfig(config gameConfig, now time.Time) gameConfig {
+	date := now.Format(time.DateOnly)
+
+	hash := fnv.New64a()
+	hash.Write([]byte(date))
+	config.seed = hash.Sum64()
+	config.dailyChallenge = true
+	config.dailyChallengeDate = date
+
+	// Use a separate RNG for the modifiers, so they don't affect the game's RNG
+	rng := rand.New(rand.NewPCG(config.seed, 0))
+	config.mode = gameModes[rng.IntN(len(gameModes))]
+	config.freeMovement = rng.IntN(2) == 0
+	config.shieldRam = rng.IntN(3) == 0
+	config.playerMoveStep = 1 + rng.IntN(2)
+	config.landingRule = []landingRule{instantGameOver, loseLifeAndPushBack, invasionMeter}[rng.IntN(3)]
+	config.respawnInvulnerabilityDuration = defaultRespawnInvulnerabilityDuration
+	config.recentreOnRespawn = false
+	config.difficulty = normalDifficulty
+	config.credits = 0 // Everyone gets one attempt at the same challenge
+	config.ship = falconShip
+
+	// Pick up to two modifiers
+	config.modifiers = 0
+	for i := 0; i < 2; i++ {
+		if rng.IntN(2) == 0 {
+			config.modifiers = config.modifiers.toggle(modifiers[rng.IntN(len(modifiers))])
+		}
+	}
+	return config
+}
+
+// Prepare today's daily challenge
+// Only the first attempt each day is scored; later attempts are practice runs
+func prepareDailyChallenge(config gameConfig, now time.Time) (gameConfig, error) {
+	config = dailyChallengeConfig(config, now)
+	date := config.dailyChallengeDate
+
+	var attempts dailyChallengeAttempts
+	if err := readDataFile(dailyChallengeFileName, &attempts); err != nil {
+		return config, err
+	}
+
+	config.dailyChallengeScored = !slices.Contains(attempts.Dates, date)
+	if config.dailyChallengeScored {
+		attempts.Dates = append(attempts.Dates, date)
+		if err := writeDataFile(dailyChallengeFileName, attempts); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
+
+// Describe the modifiers for a daily challenge
+func describeDailyChallenge(config gameConfig) string {
+	modifiers := []string{config.mode.String(), fmt.Sprintf("%s landing", config.landingRule)}
+	if config.freeMovement {
+		modifiers = append(modifiers, "free movement")
+	}
+	if config.shieldRam {
+		modifiers = append(modifiers, "ram shield")
+	}
+	if config.playerMoveStep > 1 {
+		modifiers = append(modifiers, fmt.Sprintf("move step %d", config.playerMoveStep))
+	}
+	modifiers = append(modifiers, config.modifiers.names()...)
+	return strings.Join(modifiers, ", ")
+}
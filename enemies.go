@@ -0,0 +1,273 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+const diverMoveInterval = 2 // Divers move once every this many bullet ticks
+const scorePerDiverHit = 150
+const explosionRadiusX = 2 // Exploding enemies destroy enemies up to this many columns away
+const explosionRadiusY = 1
+const enemyHitFlashDuration = 150 * time.Millisecond
+
+type enemyKind int
+
+const (
+	basicEnemy enemyKind = iota
+	splitterEnemy
+	burstEnemy
+	explodingEnemy
+	armouredEnemy
+)
+
+// How an enemy is drawn, depending on how much HP it has left
+type damageState struct {
+	glyph  rune
+	colour lipgloss.TerminalColor
+}
+
+var enemyHitFlashColour = lipgloss.AdaptiveColor{
+	Light: "0",
+	Dark:  "15",
+}
+
+type enemyKindSettings struct {
+	glyph rune
+	maxHP int
+	// Indexed by HP - 1; kinds without damage states are always drawn with their glyph
+	damageStates []damageState
+	// Chance of each enemy in a formation being this kind, which increases with the wave
+	spawnChancePercent func(wave int) int
+}
+
+var enemyKinds = map[enemyKind]enemyKindSettings{
+	basicEnemy: {
+		glyph: '$',
+		maxHP: 1,
+	},
+	splitterEnemy: {
+		glyph:              'Y',
+		maxHP:              1,
+		spawnChancePercent: func(wave int) int { return min(4+wave, 10) },
+	},
+	burstEnemy: {
+		glyph:              'W',
+		maxHP:              1,
+		spawnChancePercent: func(wave int) int { return min(3+wave, 8) },
+	},
+	explodingEnemy: {
+		glyph:              'X',
+		maxHP:              1,
+		spawnChancePercent: func(wave int) int { return min(2+wave, 6) },
+	},
+	armouredEnemy: {
+		glyph: '#',
+		maxHP: 3,
+		damageStates: []damageState{
+			{glyph: '$'},
+			{glyph: '%', colour: lipgloss.AdaptiveColor{Light: "3", Dark: "11"}},
+			{glyph: '#', colour: lipgloss.AdaptiveColor{Light: "1", Dark: "9"}},
+		},
+		// Armoured enemies start appearing from the second wave
+		spawnChancePercent: func(wave int) int { return min(3*(wave-1), 12) },
+	},
+}
+
+func (k enemyKind) settings() enemyKindSettings {
+	return enemyKinds[k]
+}
+
+type enemy struct {
+	kind          enemyKind
+	hp            int
+	hitFlashUntil time.Duration // Time on the game clock
+}
+
+func newEnemy(kind enemyKind) enemy {
+	return enemy{kind: kind, hp: kind.settings().maxHP}
+}
+
+// Enemies that take more hits to destroy are worth more
+func (e enemy) score() int {
+	return scorePerEnemyHit * e.kind.settings().maxHP
+}
+
+func (e enemy) cell(now time.Duration) cell {
+	settings := e.kind.settings()
+	c := cell{r: settings.glyph}
+	if e.hp >= 1 && e.hp <= len(settings.damageStates) {
+		c = cell{r: settings.damageStates[e.hp-1].glyph, colour: settings.damageStates[e.hp-1].colour}
+	}
+	if now < e.hitFlashUntil {
+		c.colour = enemyHitFlashColour
+	}
+	return c
+}
+
+// Pick a random kind for a new enemy, mostly basic enemies
+func pickEnemyKind(rng *rand.Rand, wave int) enemyKind {
+	kinds := make([]enemyKind, 0, len(enemyKinds))
+	for kind := range enemyKinds {
+		kinds = append(kinds, kind)
+	}
+	slices.Sort(kinds)
+
+	roll := rng.IntN(100)
+	for _, kind := range kinds {
+		spawnChancePercent := kind.settings().spawnChancePercent
+		if spawnChancePercent == nil {
+			continue
+		}
+		if roll < spawnChancePercent(wave) {
+			return kind
+		}
+		roll -= spawnChancePercent(wave)
+	}
+	return basicEnemy
+}
+
+// Reduce an enemy's HP, destroying it and awarding its score once its HP runs out
+func (gv *gameView) damageEnemy(position vector2d, damage int) {
+	e, present := gv.enemyPositions.get(position)
+	if !present {
+		return
+	}
+
+	e.hp -= damage
+	if e.hp <= 0 {
+		gv.addScore(e.score())
+		gv.destroyEnemy(position)
+		gv.dropBombPickup(position)
+		gv.dropDronePickup(position)
+		return
+	}
+	e.hitFlashUntil = gv.clock.now() + enemyHitFlashDuration
+	gv.enemyPositions.set(position, e)
+}
+
+// Called after an enemy has been removed from the formation, to trigger whatever happens when that kind of enemy dies
+func (gv *gameView) onEnemyDestroyed(e enemy, position vector2d) {
+	switch e.kind {
+	case splitterEnemy:
+		gv.splitEnemy(position)
+	case burstEnemy:
+		gv.releaseBulletBurst(position)
+	case explodingEnemy:
+		gv.explodeEnemy(position)
+	}
+}
+
+// Small fast enemy released when a splitter is destroyed, which dives diagonally down the screen
+type diver struct {
+	position vector2d
+	velocity vector2d
+}
+
+// Split into two divers heading away from each other
+func (gv *gameView) splitEnemy(position vector2d) {
+	for _, dx := range []int{-1, 1} {
+		d := diver{
+			position: vector2d{x: position.x + dx, y: position.y},
+			velocity: vector2d{x: dx, y: 1},
+		}
+		if isPositionValid(d.position) {
+			gv.divers = append(gv.divers, d)
+		}
+	}
+}
+
+// Release a spread of three bullets below the enemy
+func (gv *gameView) releaseBulletBurst(position vector2d) {
+	for dx := -1; dx <= 1; dx++ {
+		bulletPosition := vector2d{x: position.x + dx, y: position.y + 1}
+		if isPositionValid(bulletPosition) {
+			gv.enemyBullets = append(gv.enemyBullets, newEnemyBullet(plainBullet, bulletPosition))
+		}
+	}
+}
+
+// Damage every enemy within the explosion radius, which can set off other exploding enemies in a chain reaction
+func (gv *gameView) explodeEnemy(position vector2d) {
+	neighbours := make([]vector2d, 0)
+	for dy := -explosionRadiusY; dy <= explosionRadiusY; dy++ {
+		for dx := -explosionRadiusX; dx <= explosionRadiusX; dx++ {
+			neighbour := vector2d{x: position.x + dx, y: position.y + dy}
+			if gv.enemyPositions.checkIfPresent(neighbour) {
+				neighbours = append(neighbours, neighbour)
+			}
+		}
+	}
+
+	// Some may already have been destroyed by an earlier explosion in the chain, in which case this does nothing
+	for _, neighbour := range neighbours {
+		gv.damageEnemy(neighbour, 1)
+	}
+}
+
+// Move divers diagonally down the screen, bouncing off the sides, and remove them once they leave the bottom
+func (gv *gameView) updateDivers() {
+	if len(gv.divers) == 0 {
+		return
+	}
+
+	gv.diverTickCount++
+	if gv.diverTickCount < diverMoveInterval {
+		return
+	}
+	gv.diverTickCount = 0
+
+	updatedDivers := make([]diver, 0, len(gv.divers))
+	for _, d := range gv.divers {
+		if d.position.x+d.velocity.x < 0 || d.position.x+d.velocity.x >= gameViewSize.x {
+			d.velocity.x = -d.velocity.x
+		}
+		d.position.x += d.velocity.x
+		d.position.y += d.velocity.y
+
+		if isPositionValid(d.position) {
+			updatedDivers = append(updatedDivers, d)
+		}
+	}
+
+	removed := len(updatedDivers) < len(gv.divers)
+	gv.divers = updatedDivers
+	if removed {
+		gv.checkWaveCleared()
+	}
+}
+
+// Handle collisions between divers and player bullets or the player
+// Shot divers are destroyed; divers hitting the player cost a life
+func (gv *gameView) handleDiverCollisions() {
+	if len(gv.divers) == 0 {
+		return
+	}
+
+	playerBulletsMap := vectorSliceToMap(gv.playerBullets)
+
+	updatedDivers := make([]diver, 0, len(gv.divers))
+	for _, d := range gv.divers {
+		switch gv.handleMovingObjectCollisions(d.position, playerBulletsMap, false) {
+		case shotByPlayer:
+			gv.addScore(scorePerDiverHit)
+		case notHit:
+			updatedDivers = append(updatedDivers, d)
+		}
+	}
+
+	removed := len(updatedDivers) < len(gv.divers)
+	gv.divers = updatedDivers
+	gv.playerBullets = playerBulletsMap.toSlice()
+	if removed {
+		gv.checkWaveCleared()
+	}
+}
+
+func (gv *gameView) drawDivers(outputMatrix *[][]cell) {
+	for _, d := range gv.divers {
+		(*outputMatrix)[d.position.y][d.position.x] = cell{r: 'v'}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"math/rand/v2"
 	"retro-shooter-game/key_maps"
+	"slices"
 	"strings"
 	"time"
 )
@@ -15,14 +16,17 @@ import (
 var gameViewSize = vector2d{x: 50, y: 15}
 
 const enemySpacing = 1
-const enemyColumnCount = 10
-const playerMoveIncrement = 2
+const defaultEnemyRowCount = 5
+const defaultEnemyColumnCount = 10
+const playerZoneHeight = 4 // Number of rows at the bottom of the screen the player can move within in free movement mode
 const scorePerEnemyHit = 100
 const scorePerBulletHit = 50
 const playerBulletCooldownDuration = 750 * time.Millisecond
 const playerBulletCooldownMaxCount = 5
+const ramShieldChargeCount = 3
 
-type vector2dMap map[int]map[int]struct{}
+// Map of positions to values, keyed by y then x
+type vector2dMap[T any] map[int]map[int]T
 
 type status int
 
@@ -33,54 +37,173 @@ const (
 	gameLost
 	gameWon
 	lifeLost
+	waveCleared
+	timeUp
+	shopping
+	continueCountdown
+	choosingPerk
 )
 
 type gameView struct {
+	config                                     gameConfig
+	landingRule                                landingRule
+	rng                                        *rand.Rand
+	wave                                       int
+	enemyRowCount                              int
+	enemyColumnCount                           int
+	stage                                      stageType
 	playerPosition                             vector2d
-	enemyPositions                             vector2dMap
+	enemyPositions                             vector2dMap[enemy]
+	piercedPositions                           vector2dMap[struct{}] // Where piercing bullets have hit enemies since they last moved
+	heartbeat                                  int                   // Number of steps the formation has taken, for the heartbeat indicator
+	showDebugOverlay                           bool
 	enemyYOffset                               int // Easier to just store this instead of traversing through the map to find the min or max y value
 	tickCount                                  int
 	playerBullets                              []vector2d
 	score                                      int
 	status                                     status
-	enemyBullets                               []vector2d
-	livesRemaining                             int
-	lifeLostTickCount                          int
+	enemyBullets                               []enemyBullet
+	lives                                      lives
+	clock                                      gameClock
+	challengeEnemies                           []challengeEnemy
+	challengeTick                              int
+	challengeHitCount                          int
+	scrolling                                  scrollingState
+	boss                                       *boss // Nil until a boss appears
+	stats                                      playerStats
+	coins                                      int
+	shop                                       shop
+	campaignProgressError                      error
+	perks                                      []perk // Chosen so far this run
+	perkChoices                                []perk // Offered after the wave just cleared
+	selectedPerkIndex                          int
+	enemyBulletTickCount                       int
+	newlyUnlockedShips                         []shipType
+	shipUnlockError                            error
+	divers                                     []diver
+	diverTickCount                             int
+	shield                                     ability
+	dash                                       ability
+	lastMoveDirection                          vector2d // Horizontal direction the player last moved in, which dashes go in
+	smartBombs                                 int
+	detonatingSmartBomb                        bool          // Set while a smart bomb is going off, so its kills score less
+	screenFlashUntil                           time.Duration // Time on the game clock
+	bombPickups                                []vector2d
+	pickupTickCount                            int
+	drone                                      *drone // Nil until a drone pickup is collected
+	dronePickups                               []vector2d
+	hazards                                    []hazard
+	hazardTickCount                            int
+	creditsRemaining                           int
+	continueCount                              int
+	continueSecondsRemaining                   int
+	invasionCount                              int       // Number of enemies that have landed, when using the invasion meter landing rule
+	ramShieldCharges                           int       // Number of enemies the player can still destroy by contact
 	playerBulletCooldownTime                   time.Time // Cooldown period so player can't just hold down the space key
 	playerBulletCooldownCount                  int
 	displayPlayerBulletCooldownExceededMessage bool
 	// For returning to previous status when `QuitConfirmationKeys.Cancel` is pressed
 	previousStatus status
+	// Set when the game ends
+	leaderboardScores []highScore
+	highScoreIndex    int
+	leaderboardError  error
+	leaderboard       leaderboard // Kept while entering initials, to save them with the high score
+	enteringInitials  bool
+	initials          string
 }
 
-func newGameView() *gameView {
-	return &gameView{
+// Start a new game with the given config, picking a new seed (or preparing the daily challenge)
+func startGame(m model, config gameConfig) (tea.Model, tea.Cmd) {
+	if config.dailyChallenge {
+		var err error
+		if config, err = prepareDailyChallenge(config, time.Now()); err != nil {
+			tv := newTitleView()
+			tv.err = err
+			m.view = tv
+			return m, nil
+		}
+	} else {
+		config.seed = rand.Uint64()
+	}
+
+	gv := newGameView(config)
+	m.view = gv
+	return m, gv.tickCmds()
+}
+
+func newGameView(config gameConfig) *gameView {
+	key_maps.PlayingKeys.Up.SetEnabled(config.freeMovement)
+	key_maps.PlayingKeys.Down.SetEnabled(config.freeMovement)
+
+	key_maps.PlayingKeys.Shoot.SetEnabled(!config.modifiers.has(pacifistModifier))
+	key_maps.PlayingKeys.Bomb.SetEnabled(!config.modifiers.has(pacifistModifier))
+
+	settings := defaultLivesSettings
+	if config.modifiers.has(hardcoreModifier) {
+		settings.startingLives = 1
+		settings.scoreInterval = 0
+	}
+
+	landingRule := config.landingRule
+	if landingRule == modeLandingRule {
+		landingRule = config.mode.settings().landingRule
+	}
+
+	gv := &gameView{
+		config:            config,
+		landingRule:       landingRule,
+		rng:               rand.New(rand.NewPCG(config.seed, config.seed)),
+		wave:              1,
+		enemyRowCount:     defaultEnemyRowCount,
+		enemyColumnCount:  defaultEnemyColumnCount,
 		playerPosition:    vector2d{x: gameViewSize.x / 2, y: gameViewSize.y - 1},
-		enemyPositions:    generateEnemyPositions(),
 		tickCount:         0,
 		playerBullets:     make([]vector2d, 0),
+		piercedPositions:  make(vector2dMap[struct{}]),
 		score:             0,
-		enemyBullets:      make([]vector2d, 0),
-		livesRemaining:    3,
+		enemyBullets:      make([]enemyBullet, 0),
+		lives:             newLives(settings),
+		clock:             newGameClock(),
+		stats:             newPlayerStats(config),
+		smartBombs:        startingSmartBombs,
+		shield:            shieldAbility,
+		dash:              dashAbility,
+		lastMoveDirection: vector2d{x: 1, y: 0},
+		creditsRemaining:  config.credits,
+		shop:              newShop(),
 		status:            playing,
-		lifeLostTickCount: 0,
 	}
+	if config.shieldRam {
+		gv.ramShieldCharges = ramShieldChargeCount
+	}
+	config.ship.settings().applySpecial(gv)
+	if config.campaignStages != nil {
+		gv.enemyRowCount = gv.campaignStage().RowCount
+		gv.enemyColumnCount = gv.campaignStage().ColumnCount
+	}
+	gv.enemyPositions = generateEnemyPositions(gv.enemyRowCount, gv.enemyColumnCount, gv.rng, gv.wave)
+	if config.mode.settings().stage == scrollingStage && config.campaignStages == nil {
+		gv.stage = scrollingStage
+		gv.enemyPositions = make(vector2dMap[enemy])
+		gv.scrolling = gv.newScrollingState()
+	}
+	return gv
 }
 
-func generateEnemyPositions() (enemyPositions vector2dMap) {
-	const rowCount = 5
-	enemyPositions = make(vector2dMap, rowCount)
+func generateEnemyPositions(rowCount int, columnCount int, rng *rand.Rand, wave int) (enemyPositions vector2dMap[enemy]) {
+	enemyPositions = make(vector2dMap[enemy], rowCount)
 	for i := 0; i < rowCount; i++ {
-		enemyPositions[i] = make(map[int]struct{}, enemyColumnCount)
+		enemyPositions[i] = make(map[int]enemy, columnCount)
 
-		for columnIndex := 0; columnIndex < enemyColumnCount; columnIndex++ {
+		for columnIndex := 0; columnIndex < columnCount; columnIndex++ {
 			var x int
 			if i%2 == 0 {
 				x = columnIndex * 2
 			} else {
 				x = gameViewSize.x - (columnIndex * 2) - 1
 			}
-			enemyPositions[i][x] = struct{}{}
+			enemyPositions[i][x] = newEnemy(pickEnemyKind(rng, wave))
 		}
 	}
 	return
@@ -92,14 +215,29 @@ func (gv *gameView) switchToQuitConfirmationStatus() {
 }
 
 func (gv *gameView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	// Any key interrupts the demo game
+	if _, ok := msg.(tea.KeyMsg); ok && gv.config.demo {
+		m.view = newTitleView()
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch gv.status {
-		case gameLost, gameWon:
+		case gameLost, gameWon, timeUp:
+			if gv.enteringInitials {
+				gv.updateInitialsEntry(msg)
+				return m, nil
+			}
 			switch {
 			case key.Matches(msg, key_maps.GameOverKeys.Restart):
-				m.view = newGameView()
-				return m, tea.Batch(bulletTickCmd(), enemyTickCmd())
+				return startGame(m, gv.config)
+			case key.Matches(msg, key_maps.GameOverKeys.Next):
+				config := gv.config
+				config.campaignStageIndex++
+				m.view = stageIntroView{config: config}
+			case key.Matches(msg, key_maps.GameOverKeys.Title):
+				m.view = newTitleView()
 			case key.Matches(msg, key_maps.GameOverKeys.Quit):
 				gv.switchToQuitConfirmationStatus()
 			}
@@ -107,7 +245,8 @@ func (gv *gameView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 			switch {
 			case key.Matches(msg, key_maps.PauseKeys.Resume):
 				gv.status = playing
-				return m, tea.Batch(bulletTickCmd(), enemyTickCmd())
+				gv.clock.resume()
+				return m, gv.tickCmds()
 			case key.Matches(msg, key_maps.PauseKeys.Quit):
 				gv.switchToQuitConfirmationStatus()
 			}
@@ -121,116 +260,421 @@ func (gv *gameView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 		case playing:
 			switch {
 			case key.Matches(msg, key_maps.PlayingKeys.Left):
-				if gv.playerPosition.x > 1 {
-					gv.playerPosition.x -= playerMoveIncrement
-				}
+				return m, gv.movePlayer(vector2d{x: -1, y: 0})
 			case key.Matches(msg, key_maps.PlayingKeys.Right):
-				if gv.playerPosition.x < gameViewSize.x-2 {
-					gv.playerPosition.x += playerMoveIncrement
-				}
+				return m, gv.movePlayer(vector2d{x: 1, y: 0})
+			case key.Matches(msg, key_maps.PlayingKeys.Up):
+				return m, gv.movePlayer(vector2d{x: 0, y: -1})
+			case key.Matches(msg, key_maps.PlayingKeys.Down):
+				return m, gv.movePlayer(vector2d{x: 0, y: 1})
 			case key.Matches(msg, key_maps.PlayingKeys.Shoot):
-				if gv.playerBulletCooldownTime.IsZero() || time.Now().Sub(gv.playerBulletCooldownTime) >= playerBulletCooldownDuration {
-					gv.createPlayerBullet()
-					gv.playerBulletCooldownCount++
-					if gv.playerBulletCooldownCount >= playerBulletCooldownMaxCount {
-						gv.playerBulletCooldownCount = 0
-						gv.playerBulletCooldownTime = time.Now()
-					}
-				} else if !gv.displayPlayerBulletCooldownExceededMessage {
-					gv.displayPlayerBulletCooldownExceededMessage = true // For displaying message
-					return m, messageTickCmd()
+				return m, gv.shoot()
+			case key.Matches(msg, key_maps.PlayingKeys.Bomb):
+				gv.detonateSmartBomb()
+				if gv.status != playing {
+					return m, tea.Batch(gv.statusChangedCmd(), gv.lives.extraLifeCmd())
 				}
-
-				return m, nil
+				return m, gv.lives.extraLifeCmd()
+			case key.Matches(msg, key_maps.PlayingKeys.Shield):
+				gv.activateShield()
+			case key.Matches(msg, key_maps.PlayingKeys.Dash):
+				return m, gv.dashPlayer()
 			case key.Matches(msg, key_maps.PlayingKeys.Pause):
 				gv.status = paused
+				gv.clock.pause()
+			case key.Matches(msg, key_maps.PlayingKeys.Debug):
+				gv.showDebugOverlay = !gv.showDebugOverlay
+			}
+		case continueCountdown:
+			switch {
+			case key.Matches(msg, key_maps.ContinueKeys.Continue):
+				gv.continueGame()
+				return m, gv.tickCmds()
+			case key.Matches(msg, key_maps.ContinueKeys.GiveUp):
+				gv.endGame(gameLost)
 			}
-		case lifeLost:
+		case choosingPerk:
+			switch {
+			case key.Matches(msg, key_maps.PerkChoiceKeys.Up):
+				gv.movePerkSelection(-1)
+			case key.Matches(msg, key_maps.PerkChoiceKeys.Down):
+				gv.movePerkSelection(1)
+			case key.Matches(msg, key_maps.PerkChoiceKeys.Choose):
+				gv.chooseSelectedPerk()
+			}
+		case shopping:
+			switch {
+			case key.Matches(msg, key_maps.ShopKeys.Up):
+				gv.moveShopSelection(-1)
+			case key.Matches(msg, key_maps.ShopKeys.Down):
+				gv.moveShopSelection(1)
+			case key.Matches(msg, key_maps.ShopKeys.Buy):
+				gv.buySelectedUpgrade()
+			case key.Matches(msg, key_maps.ShopKeys.Done):
+				gv.clock.resume()
+				gv.startNextWave()
+				return m, gv.tickCmds()
+			}
+		case lifeLost, waveCleared:
 		}
 	case bulletTickMsg:
 		if gv.status == playing {
+			// Kept to batch with the tick, as moving or shooting can start the extra life message
+			var demoCmd tea.Cmd
+			if gv.config.demo {
+				if demoCmd = gv.updateDemoPlayer(); gv.status != playing {
+					return m, demoCmd
+				}
+			}
 			gv.updatePlayerBullets()
 			gv.updateEnemyBullets()
 			gv.handlePlayerBulletCollisions()
+			if gv.stage == challengeStage {
+				gv.handleChallengeEnemyCollisions()
+			}
+			if gv.stage == scrollingStage {
+				gv.handleScrollingCollisions()
+			}
+			gv.handleBossCollisions()
+			gv.updateDivers()
+			gv.handleDiverCollisions()
+			gv.updateDrone()
+			gv.updatePickups()
+			gv.handleBombPickupCollisions()
+			gv.handleDronePickupCollisions()
 			gv.handleEnemyBulletCollisions()
 			gv.handleBulletCollisions()
+			gv.spawnHazards()
+			gv.updateHazards()
+			gv.handleHazardCollisions()
+			gv.checkTimeLimit()
 
-			if gv.status == lifeLost && gv.lifeLostTickCount == 0 {
-				return m, tea.Batch(bulletTickCmd(), lifeLostTickCmd())
+			if gv.status != playing {
+				return m, tea.Batch(gv.statusChangedCmd(), gv.lives.extraLifeCmd(), demoCmd)
 			}
-			return m, bulletTickCmd()
+			return m, tea.Batch(bulletTickCmd(), gv.lives.extraLifeCmd(), demoCmd)
 		}
 	case enemyTickMsg:
 		if gv.status == playing {
-			gv.updateEnemies()
-			gv.handlePlayerBulletCollisions()
-			gv.createEnemyBullets()
+			if gv.stage == challengeStage {
+				gv.handleChallengeEnemyCollisions()
+				gv.updateChallengeEnemies()
+				gv.handleChallengeEnemyCollisions()
+			} else if gv.stage == scrollingStage {
+				gv.updateScrolling()
+				gv.handlePlayerBulletCollisions()
+				gv.handlePlayerEnemyCollisions()
+				gv.handleScrollingCollisions()
+			} else if gv.boss != nil {
+				gv.updateBoss()
+			} else {
+				gv.updateEnemies()
+				gv.handlePlayerBulletCollisions()
+				gv.handlePlayerEnemyCollisions()
+				gv.createEnemyBullets()
+			}
 
-			return m, enemyTickCmd()
+			if gv.status != playing {
+				return m, tea.Batch(gv.statusChangedCmd(), gv.lives.extraLifeCmd())
+			}
+			return m, tea.Batch(enemyTickCmd(gv.enemyTickInterval()), gv.lives.extraLifeCmd())
 		}
 	case lifeLostTickMsg:
-		gv.lifeLostTickCount++
-
-		if gv.lifeLostTickCount < 6 {
+		if !gv.lives.isLifeLostSequenceFinished(gv.clock.now()) {
 			return m, lifeLostTickCmd()
 		} else {
-			if gv.livesRemaining <= 0 {
-				gv.status = gameLost
-			} else {
-				gv.status = playing
+			if gv.lives.isGameOver() {
+				gv.loseGame()
+				return m, gv.statusChangedCmd()
 			}
-			gv.lifeLostTickCount = 0
-			return m, tea.Batch(bulletTickCmd(), enemyTickCmd())
+			gv.respawn()
+			gv.status = playing
+			// The last enemy may have been destroyed as the life was lost, e.g. by ramming it
+			if gv.checkWaveCleared(); gv.status != playing {
+				return m, gv.statusChangedCmd()
+			}
+			return m, gv.tickCmds()
+		}
+	case continueTickMsg:
+		if gv.status == continueCountdown {
+			gv.updateContinueCountdown()
+			return m, gv.statusChangedCmd()
 		}
+	case waveClearedTickMsg:
+		gv.openPerkChoice()
 	case messageTickMsg:
 		gv.displayPlayerBulletCooldownExceededMessage = false
+	case extraLifeMessageTickMsg:
+		gv.lives.displayExtraLifeMessage = false
 	}
 	return m, nil
 }
 
+// Move the player one step in the given direction, staying within the player zone
+func (gv *gameView) movePlayer(direction vector2d) tea.Cmd {
+	if gv.config.modifiers.has(mirroredControlsModifier) {
+		direction = vector2d{x: -direction.x, y: -direction.y}
+	}
+
+	if direction.x != 0 {
+		gv.lastMoveDirection = vector2d{x: direction.x, y: 0}
+	}
+
+	previousPosition := gv.playerPosition
+	gv.playerPosition.x = min(max(gv.playerPosition.x+(direction.x*gv.stats.moveStep), 0), gameViewSize.x-1)
+	gv.playerPosition.y = min(max(gv.playerPosition.y+direction.y, gameViewSize.y-playerZoneHeight), gameViewSize.y-1)
+
+	// The player can't move into walls (but can be hit by them when the screen scrolls)
+	if gv.stage == scrollingStage && gv.isShipInWall(gv.playerPosition) {
+		gv.playerPosition = previousPosition
+	}
+	return gv.handlePlayerMoved()
+}
+
+// Check for collisions straight away after the player moves
+// Otherwise, in free movement mode, the player could move up past an enemy bullet between bullet ticks
+func (gv *gameView) handlePlayerMoved() tea.Cmd {
+	gv.handleEnemyBulletCollisions()
+	gv.handlePlayerEnemyCollisions()
+	if gv.stage == challengeStage {
+		gv.handleChallengeEnemyCollisions()
+	}
+	gv.handleDiverCollisions()
+	gv.handleBombPickupCollisions()
+	gv.handleDronePickupCollisions()
+	gv.handleHazardCollisions()
+
+	if gv.status != playing {
+		return tea.Batch(gv.statusChangedCmd(), gv.lives.extraLifeCmd())
+	}
+	return gv.lives.extraLifeCmd()
+}
+
+func (gv *gameView) tickCmds() tea.Cmd {
+	return tea.Batch(bulletTickCmd(), enemyTickCmd(gv.enemyTickInterval()))
+}
+
+func (gv *gameView) enemyTickInterval() time.Duration {
+	interval := enemyTickInterval(gv.wave)
+	if gv.config.campaignStages != nil {
+		interval = gv.campaignStage().enemyTickInterval()
+	}
+	switch gv.stage {
+	case challengeStage:
+		interval = challengeEnemyTickInterval
+	case scrollingStage:
+		interval = scrollTickInterval
+	}
+
+	if gv.config.modifiers.has(doubleSpeedEnemiesModifier) {
+		interval /= 2
+	}
+	if gv.hasTempo() {
+		interval = max(time.Duration(float64(interval)*gv.tempoFactor()), minEnemyTickInterval)
+	}
+	return interval
+}
+
+// Start whatever drives the game after the status changes from `playing` during an update
+// (the bullet and enemy ticks stop by themselves once the status isn't `playing`)
+func (gv *gameView) statusChangedCmd() tea.Cmd {
+	switch gv.status {
+	case lifeLost:
+		return lifeLostTickCmd()
+	case waveCleared:
+		return waveClearedTickCmd()
+	case continueCountdown:
+		return continueTickCmd()
+	}
+	return nil
+}
+
+func (gv *gameView) enemyFireChancePercent() int {
+	if gv.config.campaignStages != nil {
+		return gv.campaignStage().EnemyFireChancePercent
+	}
+	return enemyFireChancePercent(gv.wave)
+}
+
+func (gv *gameView) checkTimeLimit() {
+	if gv.timeRemaining() <= 0 && gv.config.mode.settings().timeLimit > 0 {
+		gv.endGame(timeUp)
+	}
+}
+
+func (gv *gameView) timeRemaining() time.Duration {
+	return max(gv.config.mode.settings().timeLimit-gv.clock.now(), 0)
+}
+
+// Called once all enemies in the wave have been destroyed (or have landed)
+func (gv *gameView) handleWaveCleared() {
+	if gv.config.campaignStages != nil {
+		// Campaign stages with a boss aren't over until the boss has been defeated too
+		if gv.campaignStage().Boss && gv.boss == nil {
+			gv.spawnBoss()
+		} else {
+			gv.endGame(gameWon)
+		}
+	} else if gv.config.mode.settings().multipleWaves {
+		gv.status = waveCleared
+	} else {
+		gv.endGame(gameWon)
+	}
+}
+
+func (gv *gameView) startNextWave() {
+	gv.wave++
+	if isChallengeWave(gv.wave) {
+		gv.stage = challengeStage
+		gv.enemyPositions = make(vector2dMap[enemy])
+		gv.challengeEnemies = generateChallengeEnemies()
+		gv.challengeTick = 0
+		gv.challengeHitCount = 0
+	} else {
+		gv.stage = formationStage
+		gv.enemyPositions = generateEnemyPositions(gv.enemyRowCount, gv.enemyColumnCount, gv.rng, gv.wave)
+	}
+	gv.enemyYOffset = 0
+	gv.tickCount = 0
+	gv.divers = nil
+	gv.bombPickups = nil
+	gv.dronePickups = nil
+	gv.hazards = nil
+	gv.hazardTickCount = 0
+	gv.playerBullets = make([]vector2d, 0)
+	gv.enemyBullets = make([]enemyBullet, 0)
+	gv.status = playing
+}
+
+// End the game with the given status, recording the score on the leaderboard for the game mode
+func (gv *gameView) endGame(status status) {
+	gv.status = status
+
+	key_maps.GameOverKeys.Next.SetEnabled(status == gameWon && gv.hasNextCampaignStage())
+	if status == gameWon && gv.config.campaignStages != nil {
+		gv.campaignProgressError = unlockNextCampaignStage(gv.config.campaignStageIndex)
+	}
+	if !gv.config.demo {
+		gv.newlyUnlockedShips, gv.shipUnlockError = gv.unlockShips()
+	}
+
+	var lb leaderboard
+	lb, gv.leaderboardError = loadLeaderboard()
+	if gv.leaderboardError != nil {
+		return
+	}
+
+	category := gv.leaderboardCategory()
+	if gv.config.demo || (gv.config.dailyChallenge && !gv.config.dailyChallengeScored) {
+		// Demo games and practice runs of the daily challenge aren't recorded
+		gv.highScoreIndex = -1
+		gv.leaderboardScores = lb[category]
+		return
+	}
+
+	gv.highScoreIndex = lb.add(category, highScore{
+		Score:     gv.score,
+		Wave:      gv.wave,
+		Date:      time.Now(),
+		Modifiers: gv.config.modifiers.names(),
+		Credits:   gv.creditsUsed(),
+		Ship:      gv.config.ship.settings().id,
+	})
+	gv.leaderboardScores = lb[category]
+	if gv.highScoreIndex >= 0 {
+		gv.leaderboardError = lb.save()
+		// The score is saved straight away, then again with the initials once they've been entered
+		if gv.config.kiosk && gv.leaderboardError == nil {
+			gv.enteringInitials = true
+			gv.leaderboard = lb
+		}
+	}
+}
+
+func (gv *gameView) isGameOver() bool {
+	return gv.status == gameLost || gv.status == gameWon || gv.status == timeUp
+}
+
+// Fire if the bullet cooldown allows it, otherwise show the cooldown message
+func (gv *gameView) shoot() tea.Cmd {
+	if gv.config.modifiers.has(rapidFireModifier) {
+		gv.createPlayerBullet()
+		return gv.handlePlayerShot()
+	} else if !gv.isBulletCooldownActive() {
+		gv.createPlayerBullet()
+		gv.playerBulletCooldownCount++
+		if gv.playerBulletCooldownCount >= gv.stats.bulletCooldownMaxCount {
+			gv.playerBulletCooldownCount = 0
+			gv.playerBulletCooldownTime = time.Now()
+		}
+		return gv.handlePlayerShot()
+	} else if !gv.displayPlayerBulletCooldownExceededMessage {
+		gv.displayPlayerBulletCooldownExceededMessage = true // For displaying message
+		return messageTickCmd()
+	}
+	return nil
+}
+
+func (gv *gameView) isBulletCooldownActive() bool {
+	return !gv.config.modifiers.has(rapidFireModifier) && !gv.playerBulletCooldownTime.IsZero() && time.Now().Sub(gv.playerBulletCooldownTime) < gv.stats.bulletCooldownDuration
+}
+
+// Check for hits straight away after shooting
+// Otherwise a new bullet moves up before the next collision check, passing straight through an enemy right above the ship
+func (gv *gameView) handlePlayerShot() tea.Cmd {
+	gv.handlePlayerBulletCollisions()
+	if gv.stage == challengeStage {
+		gv.handleChallengeEnemyCollisions()
+	}
+	if gv.stage == scrollingStage {
+		gv.handleScrollingCollisions()
+	}
+	gv.handleBossCollisions()
+	gv.handleDiverCollisions()
+	gv.handleHazardCollisions()
+	gv.handleBulletCollisions()
+
+	if gv.status != playing {
+		return tea.Batch(gv.statusChangedCmd(), gv.lives.extraLifeCmd())
+	}
+	return gv.lives.extraLifeCmd()
+}
+
+// Fire a bullet from the player's position, plus any extra bullets side by side
 func (gv *gameView) createPlayerBullet() {
-	newBullet := vector2d{
-		x: gv.playerPosition.x,
-		y: gameViewSize.y - 2,
+	xOffsets := []int{0, -1, 1, -2, 2}
+	for _, xOffset := range xOffsets[:min(gv.stats.bulletsPerShot, len(xOffsets))] {
+		newBullet := vector2d{
+			x: gv.playerPosition.x + xOffset,
+			y: gv.playerPosition.y - 1,
+		}
+		if isPositionValid(newBullet) {
+			gv.playerBullets = append(gv.playerBullets, newBullet)
+		}
 	}
-	gv.playerBullets = append(gv.playerBullets, newBullet)
 }
 
 func (gv *gameView) updateEnemies() {
+	gv.heartbeat++
+
 	// If either end of the row is reached...
-	if gv.tickCount >= gameViewSize.x-enemyColumnCount-(enemySpacing*(enemyColumnCount-1)) {
+	if gv.tickCount >= gameViewSize.x-gv.enemyColumnCount-(enemySpacing*(gv.enemyColumnCount-1)) {
 		gv.tickCount = 0
 
-		if gv.enemyYOffset+len(gv.enemyPositions)-1 >= gameViewSize.y-2 {
-			// If enemies have reached the bottom of the screen then it's game over
-			gv.status = gameLost
+		if gv.enemyPositions.maxY() >= gameViewSize.y-2 {
+			// If enemies have reached the bottom of the screen then the consequence depends on the landing rule
+			gv.handleFormationLanded()
 		} else {
 			// Else move enemies down
-			updatedEnemyPositions := make(vector2dMap, len(gv.enemyPositions))
-			for y, xMap := range gv.enemyPositions {
-				for x := range xMap {
-					position := vector2d{x: x, y: y}
-
-					updatedPosition := position
-					updatedPosition.y++
-
-					if _, isYPresent := updatedEnemyPositions[updatedPosition.y]; !isYPresent {
-						updatedEnemyPositions[updatedPosition.y] = make(map[int]struct{}, len(gv.enemyPositions[position.y]))
-					}
-
-					updatedEnemyPositions[updatedPosition.y][updatedPosition.x] = struct{}{}
-				}
-			}
-			gv.enemyYOffset++
-			gv.enemyPositions = updatedEnemyPositions
+			gv.moveFormationDown()
 		}
 	} else {
 		gv.tickCount++
 
 		// Move enemies left/right (move alternate rows in opposite directions, so 1st row left/right, then 2nd row right/left, etc.)
-		updatedEnemyPositions := make(vector2dMap, len(gv.enemyPositions))
+		updatedEnemyPositions := make(vector2dMap[enemy], len(gv.enemyPositions))
 		for y, xMap := range gv.enemyPositions {
-			for x := range xMap {
+			for x, e := range xMap {
 				position := vector2d{x: x, y: y}
 
 				updatedPosition := position
@@ -241,17 +685,38 @@ func (gv *gameView) updateEnemies() {
 				}
 
 				if _, isYPresent := updatedEnemyPositions[updatedPosition.y]; !isYPresent {
-					updatedEnemyPositions[updatedPosition.y] = make(map[int]struct{}, len(gv.enemyPositions[position.y]))
+					updatedEnemyPositions[updatedPosition.y] = make(map[int]enemy, len(gv.enemyPositions[position.y]))
 				}
 
-				updatedEnemyPositions[updatedPosition.y][updatedPosition.x] = struct{}{}
+				updatedEnemyPositions[updatedPosition.y][updatedPosition.x] = e
 			}
 		}
 		gv.enemyPositions = updatedEnemyPositions
 	}
 }
 
+func (gv *gameView) moveFormationDown() {
+	updatedEnemyPositions := make(vector2dMap[enemy], len(gv.enemyPositions))
+	for y, xMap := range gv.enemyPositions {
+		for x, e := range xMap {
+			position := vector2d{x: x, y: y}
+
+			updatedPosition := position
+			updatedPosition.y++
+
+			if _, isYPresent := updatedEnemyPositions[updatedPosition.y]; !isYPresent {
+				updatedEnemyPositions[updatedPosition.y] = make(map[int]enemy, len(gv.enemyPositions[position.y]))
+			}
+
+			updatedEnemyPositions[updatedPosition.y][updatedPosition.x] = e
+		}
+	}
+	gv.enemyYOffset++
+	gv.enemyPositions = updatedEnemyPositions
+}
+
 func (gv *gameView) updatePlayerBullets() {
+	gv.piercedPositions = make(vector2dMap[struct{}])
 	updatedPositions := make([]vector2d, 0, len(gv.playerBullets))
 	for _, position := range gv.playerBullets {
 		position.y--
@@ -264,15 +729,21 @@ func (gv *gameView) updatePlayerBullets() {
 }
 
 func (gv *gameView) updateEnemyBullets() {
-	updatedPositions := make([]vector2d, 0, len(gv.enemyBullets))
-	for _, position := range gv.enemyBullets {
-		position.y++
+	gv.enemyBulletTickCount++
+	if gv.enemyBulletTickCount < gv.stats.enemyBulletMoveInterval {
+		return
+	}
+	gv.enemyBulletTickCount = 0
 
-		if isPositionValid(position) {
-			updatedPositions = append(updatedPositions, position)
+	updatedBullets := make([]enemyBullet, 0, len(gv.enemyBullets))
+	for _, b := range gv.enemyBullets {
+		remaining, leftScreen := b.update()
+		if leftScreen && gv.config.modifiers.has(pacifistModifier) {
+			gv.addScore(scorePerBulletDodged)
 		}
+		updatedBullets = append(updatedBullets, remaining...)
 	}
-	gv.enemyBullets = updatedPositions
+	gv.enemyBullets = updatedBullets
 }
 
 func (gv *gameView) createEnemyBullets() {
@@ -280,9 +751,21 @@ func (gv *gameView) createEnemyBullets() {
 	// If so, then randomly pick an enemy that will shoot
 	// Probability of any enemy shooting a bullet is proportional to the number of enemies
 	// Otherwise the enemies will appear more aggressive as more of them are killed
-	if rand.IntN(3) == 0 {
-		if bullet := gv.createEnemyBullet(); bullet != emptyVector2d {
-			gv.enemyBullets = append(gv.enemyBullets, bullet)
+	attemptCount := 1
+	if gv.config.modifiers.has(rapidFireModifier) {
+		attemptCount = 2
+	}
+
+	for i := 0; i < attemptCount; i++ {
+		if gv.rng.IntN(100) < gv.enemyFireChancePercent() {
+			if position := gv.createEnemyBullet(); position != emptyVector2d {
+				kind := pickProjectileKind(gv.rng, gv.wave)
+				if kind == laserProjectile {
+					// Lasers fire from just below the enemy, so the beam doesn't hide it
+					position.y++
+				}
+				gv.enemyBullets = append(gv.enemyBullets, newEnemyBullet(kind, position))
+			}
 		}
 	}
 }
@@ -293,8 +776,8 @@ func (gv *gameView) createEnemyBullet() vector2d {
 	for y, xs := range gv.enemyPositions {
 		containsValidXValues := false
 		for x := range xs {
-			// Currently the player can only move in increments of 2, so only pick positions that would actually hit the player
-			if gv.canCollideWithPlayer(x) {
+			// Only pick positions that could actually hit the player
+			if gv.canCollideWithPlayer(vector2d{x: x, y: y}) {
 				containsValidXValues = true
 				break
 			}
@@ -309,19 +792,22 @@ func (gv *gameView) createEnemyBullet() vector2d {
 		return emptyVector2d
 	}
 
-	yIndex := rand.IntN(len(ys))
+	// Sort so the same enemies are picked for the same seed, as map iteration order is random
+	slices.Sort(ys)
+	yIndex := gv.rng.IntN(len(ys))
 	y := ys[yIndex]
 
 	// Convert map to slice of keys (x values), then randomly pick one
 	xs := make([]int, 0, len(gv.enemyPositions[y]))
 	for x := range gv.enemyPositions[y] {
-		// Currently the player can only move in increments of 2, so only pick positions that would actually hit the player
-		if gv.canCollideWithPlayer(x) {
+		// Only pick positions that could actually hit the player
+		if gv.canCollideWithPlayer(vector2d{x: x, y: y}) {
 			xs = append(xs, x)
 		}
 	}
 
-	xIndex := rand.IntN(len(xs))
+	slices.Sort(xs)
+	xIndex := gv.rng.IntN(len(xs))
 	x := xs[xIndex]
 
 	enemyBulletPosition := vector2d{x: x, y: y}
@@ -329,25 +815,28 @@ func (gv *gameView) createEnemyBullet() vector2d {
 	return enemyBulletPosition
 }
 
-func (gv *gameView) canCollideWithPlayer(x int) bool {
-	return (x % playerMoveIncrement) == (gv.playerPosition.x % playerMoveIncrement)
+// Whether a bullet fired from the given position could ever hit the player
+// Every column is reachable by the player, but enemies level with or below the player (possible in free movement mode)
+// can't hit it as bullets only travel downwards
+func (gv *gameView) canCollideWithPlayer(position vector2d) bool {
+	return position.y < gv.playerPosition.y
 }
 
 // Handle collisions between player bullets and enemies
-// Remove player bullet and enemy
+// Each hit damages the enemy and removes the bullet, unless the player has piercing shots
 func (gv *gameView) handlePlayerBulletCollisions() {
 	updatedBulletPositions := make([]vector2d, 0, len(gv.playerBullets))
 	for _, position := range gv.playerBullets {
-		collision := gv.enemyPositions.checkIfPresent(position)
-		if collision {
-			gv.enemyPositions.delete(position)
-
-			gv.score += scorePerEnemyHit
+		// A piercing bullet stays where it hit until it next moves, so don't let it hit again in the meantime
+		collision := gv.enemyPositions.checkIfPresent(position) && !gv.piercedPositions.checkIfPresent(position)
+		if !collision {
+			updatedBulletPositions = append(updatedBulletPositions, position)
+			continue
+		}
 
-			if gv.enemyPositions.count() == 0 {
-				gv.status = gameWon
-			}
-		} else {
+		gv.damageEnemy(position, 1)
+		if gv.stats.piercingShots {
+			gv.piercedPositions.set(position, struct{}{})
 			updatedBulletPositions = append(updatedBulletPositions, position)
 		}
 	}
@@ -355,64 +844,214 @@ func (gv *gameView) handlePlayerBulletCollisions() {
 	gv.playerBullets = updatedBulletPositions
 }
 
+// Handle collisions between enemies and the player
+// The enemy is destroyed, either costing a life or, if the player has any ram shield charges left, a charge
+func (gv *gameView) handlePlayerEnemyCollisions() {
+	if gv.drone != nil && gv.enemyPositions.checkIfPresent(gv.drone.position) {
+		gv.destroyEnemy(gv.drone.position)
+		gv.destroyDrone()
+	}
+
+	var position vector2d
+	found := false
+	for dx := -gv.stats.hitboxWidth / 2; dx <= gv.stats.hitboxWidth/2 && !found; dx++ {
+		position = vector2d{x: gv.playerPosition.x + dx, y: gv.playerPosition.y}
+		found = gv.enemyPositions.checkIfPresent(position)
+	}
+	if !found {
+		return
+	}
+
+	if gv.ramShieldCharges > 0 {
+		gv.ramShieldCharges--
+		e, _ := gv.enemyPositions.get(position)
+		gv.addScore(e.score())
+		gv.destroyEnemy(position)
+	} else if gv.isPlayerVulnerable() {
+		gv.loseLife()
+		gv.destroyEnemy(position)
+	}
+}
+
+// Remove an enemy, triggering whatever happens when that kind of enemy is destroyed
+func (gv *gameView) destroyEnemy(position vector2d) {
+	e, present := gv.enemyPositions.get(position)
+	if !present {
+		return
+	}
+	gv.enemyPositions.delete(position)
+
+	gv.onEnemyDestroyed(e, position)
+
+	gv.checkWaveCleared()
+}
+
+// The wave is cleared once the formation and any divers it released are gone
+// Called whenever one of them is removed, so a chain reaction or boss stage only clears the wave once
+func (gv *gameView) checkWaveCleared() {
+	// Enemies keep arriving throughout the scrolling stage, so it isn't over when they've all been destroyed
+	// If a life was lost at the same time, this is checked again once the player respawns
+	if gv.stage != formationStage || gv.boss != nil || gv.status != playing {
+		return
+	}
+	if gv.enemyPositions.count() == 0 && len(gv.divers) == 0 {
+		gv.handleWaveCleared()
+	}
+}
+
 // Handle collisions between enemy bullets and player
-// Remove the enemy bullet and decrement lives
+// Remove the enemy bullet and decrement lives; lasers carry on firing after hitting something
 func (gv *gameView) handleEnemyBulletCollisions() {
-	updatedBulletPositions := make([]vector2d, 0, len(gv.enemyBullets))
-	for _, bulletPosition := range gv.enemyBullets {
-		if gv.playerPosition == bulletPosition {
-			gv.livesRemaining--
-			gv.status = lifeLost
-		} else {
-			updatedBulletPositions = append(updatedBulletPositions, bulletPosition)
+	updatedBullets := make([]enemyBullet, 0, len(gv.enemyBullets))
+	for _, b := range gv.enemyBullets {
+		hit := true
+		switch {
+		case gv.isDroneHitBy(b):
+			gv.destroyDrone()
+		case gv.isPlayerHitBy(b) && gv.isShieldActive():
+			// Absorbed by the shield
+		case gv.isPlayerHitBy(b) && gv.isPlayerVulnerable():
+			gv.loseLife()
+		default:
+			hit = false
+		}
+		if !hit || b.kind == laserProjectile {
+			updatedBullets = append(updatedBullets, b)
+		}
+	}
+	gv.enemyBullets = updatedBullets
+}
+
+type collisionOutcome int
+
+const (
+	notHit collisionOutcome = iota
+	shotByPlayer
+	hitSomething // Hit the drone or the player, or an enemy for objects that can hit enemies
+)
+
+// Handle collisions between something moving down the screen (e.g. a diver or hazard) and player bullets, enemies,
+// the drone or the player, removing any player bullet that hits it from the map
+// The object is destroyed by anything it hits; what happens to it when it's shot is up to the caller
+func (gv *gameView) handleMovingObjectCollisions(position vector2d, playerBulletsMap vector2dMap[struct{}], hitsEnemies bool) collisionOutcome {
+	// Also check the point below the object, as bullets and objects moving towards each other can pass without landing on the same point
+	for _, bulletPosition := range []vector2d{position, {x: position.x, y: position.y + 1}} {
+		if playerBulletsMap.checkIfPresent(bulletPosition) {
+			playerBulletsMap.delete(bulletPosition)
+			return shotByPlayer
 		}
 	}
-	gv.enemyBullets = updatedBulletPositions
+
+	switch {
+	case hitsEnemies && gv.enemyPositions.checkIfPresent(position):
+		gv.destroyEnemy(position)
+	case gv.isDroneAt(position):
+		gv.destroyDrone()
+	case gv.isPlayerAt(position) && gv.isPlayerVulnerable():
+		gv.loseLife()
+	default:
+		return notHit
+	}
+	return hitSomething
+}
+
+func (gv *gameView) isPlayerVulnerable() bool {
+	return gv.status == playing && !gv.lives.isInvulnerable(gv.clock.now())
+}
+
+func (gv *gameView) loseLife() {
+	gv.lives.lose(gv.clock.now())
+	gv.status = lifeLost
+}
+
+// Put the player back into play after losing a life, clearing any enemy bullets close to the ship
+func (gv *gameView) respawn() {
+	if gv.config.recentreOnRespawn {
+		gv.playerPosition = vector2d{x: gameViewSize.x / 2, y: gameViewSize.y - 1}
+	}
+	if gv.stage == scrollingStage {
+		gv.respawnInScrollingStage()
+	}
+
+	updatedBullets := make([]enemyBullet, 0, len(gv.enemyBullets))
+	for _, b := range gv.enemyBullets {
+		if abs(b.position.x-gv.playerPosition.x) > respawnBulletClearRadius || abs(b.position.y-gv.playerPosition.y) > respawnBulletClearRadius {
+			updatedBullets = append(updatedBullets, b)
+		}
+	}
+	gv.enemyBullets = updatedBullets
+
+	gv.lives.respawn(gv.clock.now(), gv.config.respawnInvulnerabilityDuration)
+}
+
+func (gv *gameView) addScore(points int) {
+	previousScore := gv.score
+	if gv.detonatingSmartBomb {
+		points = points * smartBombScorePercent / 100
+	}
+	gv.score += int(float64(points) * gv.config.modifiers.scoreMultiplier())
+	gv.updateCoins(previousScore)
+	gv.lives.updateScore(gv.score)
 }
 
 // Handle collisions between enemy bullets and player bullets
-// Remove both bullets (so player can shoot the enemy bullets to destroy them)
+// What happens depends on the kind of enemy projectile: most are shot down along with the player bullet,
+// bolts absorb player bullets and lasers can't be shot at all
 func (gv *gameView) handleBulletCollisions() {
 	playerBulletsMap := vectorSliceToMap(gv.playerBullets)
-	enemyBulletsMap := vectorSliceToMap(gv.enemyBullets)
 
-	// Iterate through one of the maps and remove any matching items from both maps
-	for y, xMap := range playerBulletsMap {
-		for x := range xMap {
-			playerBullet := vector2d{x: x, y: y}
-			if enemyBulletsMap.checkIfPresent(playerBullet) {
-				playerBulletsMap.delete(playerBullet)
-				enemyBulletsMap.delete(playerBullet)
-				gv.score += scorePerBulletHit
-			}
+	updatedEnemyBullets := make([]enemyBullet, 0, len(gv.enemyBullets))
+	for _, b := range gv.enemyBullets {
+		settings := b.kind.settings()
+		if settings.whenShot == passesThrough {
+			updatedEnemyBullets = append(updatedEnemyBullets, b)
+			continue
+		}
 
-			// Note that bullets with an even vertical gap won't actually collide on the same point
-			// Hence also checking the point above `playerBullet`
-			pointAbovePlayerBullet := vector2d{x: playerBullet.x, y: playerBullet.y + 1}
-			if enemyBulletsMap.checkIfPresent(pointAbovePlayerBullet) {
-				playerBulletsMap.delete(playerBullet)
-				enemyBulletsMap.delete(pointAbovePlayerBullet)
-				gv.score += scorePerBulletHit
-			}
+		// Note that bullets with an even vertical gap won't actually collide on the same point
+		// Hence also checking the point above the enemy bullet
+		playerBullet := b.position
+		if !playerBulletsMap.checkIfPresent(playerBullet) {
+			playerBullet = vector2d{x: b.position.x, y: b.position.y - 1}
+		}
+		if !playerBulletsMap.checkIfPresent(playerBullet) {
+			updatedEnemyBullets = append(updatedEnemyBullets, b)
+			continue
+		}
+
+		playerBulletsMap.delete(playerBullet)
+		if settings.whenShot == absorbs {
+			updatedEnemyBullets = append(updatedEnemyBullets, b)
+		} else {
+			gv.addScore(settings.scoreWhenShot)
 		}
 	}
 
 	gv.playerBullets = playerBulletsMap.toSlice()
-	gv.enemyBullets = enemyBulletsMap.toSlice()
+	gv.enemyBullets = updatedEnemyBullets
 }
 
-func vectorSliceToMap(s []vector2d) (m vector2dMap) {
-	m = make(vector2dMap)
+func vectorSliceToMap(s []vector2d) (m vector2dMap[struct{}]) {
+	m = make(vector2dMap[struct{}])
 	for _, bullet := range s {
-		if _, present := m[bullet.y]; !present {
-			m[bullet.y] = make(map[int]struct{})
-		}
-		m[bullet.y][bullet.x] = struct{}{}
+		m.set(bullet, struct{}{})
 	}
 	return
 }
 
-func (m vector2dMap) toSlice() (s []vector2d) {
+func (m vector2dMap[T]) set(v vector2d, value T) {
+	if _, present := m[v.y]; !present {
+		m[v.y] = make(map[int]T)
+	}
+	m[v.y][v.x] = value
+}
+
+func (m vector2dMap[T]) get(v vector2d) (value T, present bool) {
+	value, present = m[v.y][v.x]
+	return
+}
+
+func (m vector2dMap[T]) toSlice() (s []vector2d) {
 	s = make([]vector2d, 0)
 	for y, xMap := range m {
 		for x := range xMap {
@@ -422,7 +1061,7 @@ func (m vector2dMap) toSlice() (s []vector2d) {
 	return
 }
 
-func (m vector2dMap) delete(v vector2d) {
+func (m vector2dMap[T]) delete(v vector2d) {
 	delete(m[v.y], v.x)
 
 	// If the inner map is empty then delete corresponding entry in outer map as no longer needed
@@ -431,7 +1070,7 @@ func (m vector2dMap) delete(v vector2d) {
 	}
 }
 
-func (m vector2dMap) checkIfPresent(v vector2d) bool {
+func (m vector2dMap[T]) checkIfPresent(v vector2d) bool {
 	xMap, yPresent := m[v.y]
 	if !yPresent {
 		return false
@@ -441,7 +1080,15 @@ func (m vector2dMap) checkIfPresent(v vector2d) bool {
 	return xPresent
 }
 
-func (m vector2dMap) count() (count int) {
+func (m vector2dMap[T]) maxY() (maxY int) {
+	maxY = -1
+	for y := range m {
+		maxY = max(maxY, y)
+	}
+	return
+}
+
+func (m vector2dMap[T]) count() (count int) {
 	count = 0
 	for _, xMap := range m {
 		count += len(xMap)
@@ -457,20 +1104,72 @@ func (gv *gameView) draw(m model) string {
 		Padding(0, 1)
 
 	outputMatrix := newOutputMatrix()
+	if gv.stage == scrollingStage {
+		gv.drawScenery(&outputMatrix)
+	}
 	gv.drawEnemies(&outputMatrix)
+	gv.drawChallengeEnemies(&outputMatrix)
+	gv.drawDivers(&outputMatrix)
+	gv.drawHazards(&outputMatrix)
+	gv.drawBombPickups(&outputMatrix)
+	gv.drawDronePickups(&outputMatrix)
+	gv.drawBoss(&outputMatrix)
 	gv.drawPlayerBullets(&outputMatrix)
 	gv.drawEnemyBullets(&outputMatrix)
 	gv.drawPlayer(&outputMatrix)
+	gv.drawDrone(&outputMatrix)
+	gv.drawShield(&outputMatrix)
 
 	mainString := outputMatrixToString(outputMatrix)
+	if gv.isScreenFlashing() {
+		mainString = lipgloss.NewStyle().Reverse(true).Render(mainString)
+	}
 	scoreString := fmt.Sprintf("Score: %d", gv.score)
-	livesString := fmt.Sprintf("Lives: %d", gv.livesRemaining)
+	livesString := fmt.Sprintf("Lives: %d", gv.lives.remaining)
+	if gv.lives.displayExtraLifeMessage {
+		livesString = extraLifeStyle.Render(livesString + " 1UP!")
+	}
 	statusString := gv.getStatusString()
 
+	hudString := fmt.Sprintf("%s; %s", scoreString, livesString)
+	if gv.config.mode.settings().multipleWaves {
+		hudString = fmt.Sprintf("%s; Wave: %d; Coins: %d", hudString, gv.wave, gv.coins)
+		if gv.stage == challengeStage {
+			hudString += " (challenge)"
+		}
+	}
+	if gv.config.mode.settings().timeLimit > 0 {
+		hudString = fmt.Sprintf("%s; Time: %s", hudString, formatTimeRemaining(gv.timeRemaining()))
+	}
+	if !gv.config.modifiers.has(pacifistModifier) {
+		hudString = fmt.Sprintf("%s; Bombs: %d", hudString, gv.smartBombs)
+	}
+	hudString = fmt.Sprintf("%s; %s; %s", hudString, gv.shield.getMeterString("Shield", gv.clock.now()), gv.dash.getMeterString("Dash", gv.clock.now()))
+	if gv.config.shieldRam || gv.ramShieldCharges > 0 {
+		hudString = fmt.Sprintf("%s; Ram shield: %d", hudString, gv.ramShieldCharges)
+	}
+	if gv.config.campaignStages != nil {
+		hudString = fmt.Sprintf("%s; Stage %d", hudString, gv.config.campaignStageIndex+1)
+	}
+	if gv.stage == scrollingStage {
+		hudString = fmt.Sprintf("%s; %s", hudString, gv.getScrollingHUDString())
+	} else if gv.boss != nil {
+		hudString = fmt.Sprintf("%s; %s", hudString, gv.getBossHUDString())
+	}
+	if gv.landingRule == invasionMeter && gv.stage != scrollingStage {
+		hudString = fmt.Sprintf("%s; %s", hudString, gv.getInvasionMeterString())
+	}
+	if gv.hasTempo() {
+		hudString = fmt.Sprintf("%s; %s", hudString, gv.getTempoHUDString())
+	}
+	if gv.showDebugOverlay {
+		hudString = lipgloss.JoinVertical(lipgloss.Left, hudString, gv.getDebugOverlayString())
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		style.Render(mainString),
-		fmt.Sprintf("%s; %s", scoreString, livesString),
+		hudString,
 		lipgloss.NewStyle().PaddingTop(1).Render(statusString),
 		gv.getHelpString(m),
 	)
@@ -479,21 +1178,46 @@ func (gv *gameView) draw(m model) string {
 func (gv *gameView) getStatusString() string {
 	switch gv.status {
 	case gameLost:
-		return "Game over!"
+		return gv.getGameOverString("Game over!")
+	case timeUp:
+		return gv.getGameOverString(fmt.Sprintf("Time's up! Cleared %d wave(s)", gv.wave-1))
+	case choosingPerk:
+		return gv.getPerkChoiceString()
+	case shopping:
+		return gv.getShopString()
+	case continueCountdown:
+		return gv.getContinueString()
+	case waveCleared:
+		if gv.stage == challengeStage {
+			return gv.getChallengeResultString()
+		}
+		return fmt.Sprintf("Wave %d cleared!", gv.wave)
 	case paused:
+		if len(gv.perks) > 0 {
+			return "Paused\n" + secondaryTextStyle.Render(gv.getPerksString())
+		}
 		return "Paused"
 	case gameWon:
-		return "You win! All enemies destroyed!"
+		if gv.config.campaignStages != nil {
+			return gv.getGameOverString(fmt.Sprintf("Stage %d cleared!", gv.config.campaignStageIndex+1))
+		}
+		if gv.stage == scrollingStage {
+			return gv.getGameOverString("You win! Boss destroyed!")
+		}
+		return gv.getGameOverString("You win! All enemies destroyed!")
 	case lifeLost:
 		return "Lost a life!"
 	case playing:
 		if gv.displayPlayerBulletCooldownExceededMessage {
-			playerBulletCooldownTimeRemaining := playerBulletCooldownDuration - time.Now().Sub(gv.playerBulletCooldownTime).Truncate(time.Millisecond)
+			playerBulletCooldownTimeRemaining := gv.stats.bulletCooldownDuration - time.Now().Sub(gv.playerBulletCooldownTime).Truncate(time.Millisecond)
 			if playerBulletCooldownTimeRemaining < 0 {
 				playerBulletCooldownTimeRemaining = 0
 			}
 			return fmt.Sprintf("Can't shoot; cooldown exceeded (%s remaining)", playerBulletCooldownTimeRemaining)
 		}
+		if gv.config.demo {
+			return "Demo; press any key"
+		}
 		return ""
 	case quitConfirmation:
 		return "Are you sure you want to quit?"
@@ -501,9 +1225,63 @@ func (gv *gameView) getStatusString() string {
 	return ""
 }
 
+func (gv *gameView) getGameOverString(message string) string {
+	var leaderboardString string
+	if gv.leaderboardError != nil {
+		leaderboardString = fmt.Sprintf("Couldn't update high scores: %v", gv.leaderboardError)
+	} else {
+		leaderboardString = drawLeaderboard(fmt.Sprintf("%s high scores:", gv.getLeaderboardTitle()), gv.leaderboardScores, gv.highScoreIndex)
+	}
+	if gv.config.modifiers != 0 {
+		message += "\nModifiers: " + gv.config.modifiers.String()
+	}
+	if len(gv.perks) > 0 {
+		message += "\n" + gv.getPerksString()
+	}
+	if gv.campaignProgressError != nil {
+		message += fmt.Sprintf("\nCouldn't save campaign progress: %v", gv.campaignProgressError)
+	}
+	for _, ship := range gv.newlyUnlockedShips {
+		message += "\n" + extraLifeStyle.Render(fmt.Sprintf("New ship unlocked: %s!", ship))
+	}
+	if gv.shipUnlockError != nil {
+		message += fmt.Sprintf("\nCouldn't save unlocked ships: %v", gv.shipUnlockError)
+	}
+	if gv.config.dailyChallenge && !gv.config.dailyChallengeScored {
+		message += "\n" + secondaryTextStyle.Render("Practice run; today's scored attempt has already been used")
+	}
+	if gv.enteringInitials {
+		message += "\n" + gv.getInitialsEntryString()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, message, lipgloss.NewStyle().PaddingTop(1).Render(leaderboardString))
+}
+
+func (gv *gameView) leaderboardCategory() string {
+	if gv.config.campaignStages != nil {
+		return fmt.Sprintf("campaign-%d", gv.config.campaignStageIndex+1)
+	}
+	if gv.config.dailyChallenge {
+		return dailyChallengeLeaderboardCategory(gv.config.dailyChallengeDate)
+	}
+	return gv.config.mode.settings().leaderboardCategory
+}
+
+func (gv *gameView) getLeaderboardTitle() string {
+	if gv.config.campaignStages != nil {
+		return fmt.Sprintf("Stage %d (%s)", gv.config.campaignStageIndex+1, gv.campaignStage().Name)
+	}
+	if gv.config.dailyChallenge {
+		return fmt.Sprintf("Daily challenge (%s)", gv.config.dailyChallengeDate)
+	}
+	return gv.config.mode.String()
+}
+
 func (gv *gameView) getHelpString(m model) string {
+	if gv.enteringInitials {
+		return m.help.View(key_maps.InitialsKeys)
+	}
 	switch gv.status {
-	case gameLost:
+	case gameLost, timeUp:
 		return m.help.View(key_maps.GameOverKeys)
 	case paused:
 		return m.help.View(key_maps.PauseKeys)
@@ -513,58 +1291,76 @@ func (gv *gameView) getHelpString(m model) string {
 		return m.help.View(key_maps.GameOverKeys)
 	case playing:
 		return m.help.View(key_maps.PlayingKeys)
-	case lifeLost:
+	case choosingPerk:
+		return m.help.View(key_maps.PerkChoiceKeys)
+	case shopping:
+		return m.help.View(key_maps.ShopKeys)
+	case continueCountdown:
+		return m.help.View(key_maps.ContinueKeys)
+	case lifeLost, waveCleared:
 		return ""
 	}
 	return ""
 }
 
-func newOutputMatrix() (outputMatrix [][]rune) {
-	outputMatrix = make([][]rune, gameViewSize.y)
+// A single character of the game area, with an optional colour
+type cell struct {
+	r      rune
+	colour lipgloss.TerminalColor
+}
+
+func newOutputMatrix() (outputMatrix [][]cell) {
+	outputMatrix = make([][]cell, gameViewSize.y)
 	for i := range outputMatrix {
-		outputMatrix[i] = make([]rune, gameViewSize.x)
+		outputMatrix[i] = make([]cell, gameViewSize.x)
 	}
 	return
 }
 
-func (gv *gameView) drawEnemies(outputMatrix *[][]rune) {
+func (gv *gameView) drawEnemies(outputMatrix *[][]cell) {
 	for y, xMap := range gv.enemyPositions {
-		for x := range xMap {
-			(*outputMatrix)[y][x] = '$'
+		for x, e := range xMap {
+			(*outputMatrix)[y][x] = e.cell(gv.clock.now())
 		}
 	}
 }
 
-func (gv *gameView) drawPlayerBullets(outputMatrix *[][]rune) {
+func (gv *gameView) drawPlayerBullets(outputMatrix *[][]cell) {
 	for _, position := range gv.playerBullets {
-		(*outputMatrix)[position.y][position.x] = '.'
-	}
-}
-
-func (gv *gameView) drawEnemyBullets(outputMatrix *[][]rune) {
-	for _, position := range gv.enemyBullets {
-		(*outputMatrix)[position.y][position.x] = '.'
+		(*outputMatrix)[position.y][position.x] = cell{r: '.'}
 	}
 }
 
-func (gv *gameView) drawPlayer(outputMatrix *[][]rune) {
+func (gv *gameView) drawPlayer(outputMatrix *[][]cell) {
 	var playerRune rune
-	if gv.status != lifeLost || gv.lifeLostTickCount%2 == 0 {
+	if gv.lives.isPlayerVisible(gv.clock.now(), gv.status) {
 		playerRune = '*'
 	} else {
 		playerRune = ' '
 	}
-	(*outputMatrix)[gv.playerPosition.y][gv.playerPosition.x] = playerRune
+	(*outputMatrix)[gv.playerPosition.y][gv.playerPosition.x] = cell{r: playerRune}
+
+	// Wider ships have wings either side
+	for dx := 1; dx <= gv.stats.hitboxWidth/2; dx++ {
+		for _, x := range []int{gv.playerPosition.x - dx, gv.playerPosition.x + dx} {
+			if x >= 0 && x < gameViewSize.x && playerRune != ' ' {
+				(*outputMatrix)[gv.playerPosition.y][x] = cell{r: '='}
+			}
+		}
+	}
 }
 
-func outputMatrixToString(outputMatrix [][]rune) string {
+func outputMatrixToString(outputMatrix [][]cell) string {
 	var sb strings.Builder
 	for y, outputMatrixRow := range outputMatrix {
-		for _, outputMatrixRune := range outputMatrixRow {
-			if outputMatrixRune == 0 {
+		for _, outputMatrixCell := range outputMatrixRow {
+			switch {
+			case outputMatrixCell.r == 0:
 				sb.WriteRune(' ')
-			} else {
-				sb.WriteRune(outputMatrixRune)
+			case outputMatrixCell.colour != nil:
+				sb.WriteString(lipgloss.NewStyle().Foreground(outputMatrixCell.colour).Render(string(outputMatrixCell.r)))
+			default:
+				sb.WriteRune(outputMatrixCell.r)
 			}
 		}
 
@@ -575,6 +1371,18 @@ func outputMatrixToString(outputMatrix [][]rune) string {
 	return sb.String()
 }
 
+func formatTimeRemaining(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
 func isPositionValid(position vector2d) bool {
 	return position.x >= 0 && position.x < gameViewSize.x && position.y >= 0 && position.y < gameViewSize.y
 }
@@ -0,0 +1,119 @@
+package main
+
+const hazardMoveInterval = 3 // Hazards move once every this many bullet ticks
+const hazardMaxSize = 3
+
+// Asteroids and other debris drifting across the screen, independently of the enemy formation
+// Large hazards split into two smaller ones when shot
+type hazard struct {
+	position vector2d
+	velocity vector2d
+	size     int
+}
+
+var hazardRunes = map[int]rune{
+	1: 'o',
+	2: 'O',
+	3: '@',
+}
+
+// Smaller hazards are harder to hit so are worth more
+var scorePerHazardHit = map[int]int{
+	1: 100,
+	2: 50,
+	3: 20,
+}
+
+// Chance of a new hazard appearing on each bullet tick; more hazards appear in later waves
+func hazardSpawnChancePercent(wave int) int {
+	return min(wave, 8)
+}
+
+func (gv *gameView) spawnHazards() {
+	if gv.stage == challengeStage || gv.rng.IntN(100) >= hazardSpawnChancePercent(gv.wave) {
+		return
+	}
+
+	var h hazard
+	h.size = 1 + gv.rng.IntN(hazardMaxSize)
+	if gv.rng.IntN(2) == 0 {
+		// Drift down from the top, possibly diagonally
+		h.position = vector2d{x: gv.rng.IntN(gameViewSize.x), y: 0}
+		h.velocity = vector2d{x: gv.rng.IntN(3) - 1, y: 1}
+	} else {
+		// Drift across from either side, above the player
+		y := gv.rng.IntN(gameViewSize.y - playerZoneHeight)
+		if gv.rng.IntN(2) == 0 {
+			h.position = vector2d{x: 0, y: y}
+			h.velocity = vector2d{x: 1, y: 0}
+		} else {
+			h.position = vector2d{x: gameViewSize.x - 1, y: y}
+			h.velocity = vector2d{x: -1, y: 0}
+		}
+	}
+	gv.hazards = append(gv.hazards, h)
+}
+
+func (gv *gameView) updateHazards() {
+	gv.hazardTickCount++
+	if gv.hazardTickCount < hazardMoveInterval {
+		return
+	}
+	gv.hazardTickCount = 0
+
+	updatedHazards := make([]hazard, 0, len(gv.hazards))
+	for _, h := range gv.hazards {
+		h.position.x += h.velocity.x
+		h.position.y += h.velocity.y
+
+		if isPositionValid(h.position) {
+			updatedHazards = append(updatedHazards, h)
+		}
+	}
+	gv.hazards = updatedHazards
+}
+
+// Handle collisions between hazards and player bullets, enemies and the player
+// Shot hazards split into smaller pieces; hazards hitting an enemy or the player destroy it (or cost a life) and are destroyed too
+func (gv *gameView) handleHazardCollisions() {
+	playerBulletsMap := vectorSliceToMap(gv.playerBullets)
+
+	updatedHazards := make([]hazard, 0, len(gv.hazards))
+	for _, h := range gv.hazards {
+		switch gv.handleMovingObjectCollisions(h.position, playerBulletsMap, true) {
+		case shotByPlayer:
+			gv.addScore(scorePerHazardHit[h.size])
+			updatedHazards = append(updatedHazards, h.split()...)
+		case notHit:
+			updatedHazards = append(updatedHazards, h)
+		}
+	}
+	gv.hazards = updatedHazards
+	gv.playerBullets = playerBulletsMap.toSlice()
+}
+
+// Split a hazard into two smaller hazards moving apart, or none if it's already the smallest size
+func (h hazard) split() []hazard {
+	if h.size <= 1 {
+		return nil
+	}
+
+	pieces := make([]hazard, 0, 2)
+	for _, dx := range []int{-1, 1} {
+		piece := hazard{
+			position: vector2d{x: h.position.x + dx, y: h.position.y},
+			velocity: vector2d{x: dx, y: max(h.velocity.y, 0)},
+			size:     h.size - 1,
+		}
+		if isPositionValid(piece.position) {
+			pieces = append(pieces, piece)
+		}
+	}
+	return pieces
+}
+
+func (gv *gameView) drawHazards(outputMatrix *[][]cell) {
+	for _, h := range gv.hazards {
+		(*outputMatrix)[h.position.y][h.position.x] = cell{r: hazardRunes[h.size]}
+	}
+}
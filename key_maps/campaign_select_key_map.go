@@ -0,0 +1,37 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type campaignSelectKeyMap struct {
+	Up    key.Binding
+	Down  key.Binding
+	Start key.Binding
+	Back  key.Binding
+}
+
+var CampaignSelectKeys = campaignSelectKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+	),
+	Start: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("↵", "select"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+}
+
+func (k campaignSelectKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Start, k.Back}
+}
+
+func (k campaignSelectKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Start, k.Back}}
+}
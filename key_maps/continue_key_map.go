@@ -0,0 +1,27 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type continueKeyMap struct {
+	Continue key.Binding
+	GiveUp   key.Binding
+}
+
+var ContinueKeys = continueKeyMap{
+	Continue: key.NewBinding(
+		key.WithKeys("enter", "y"),
+		key.WithHelp("↵/y", "continue"),
+	),
+	GiveUp: key.NewBinding(
+		key.WithKeys("esc", "n"),
+		key.WithHelp("esc/n", "give up"),
+	),
+}
+
+func (k continueKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Continue, k.GiveUp}
+}
+
+func (k continueKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Continue, k.GiveUp}}
+}
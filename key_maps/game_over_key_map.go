@@ -4,6 +4,8 @@ import "github.com/charmbracelet/bubbles/key"
 
 type gameOverKeyMap struct {
 	Restart key.Binding
+	Next    key.Binding
+	Title   key.Binding
 	Quit    key.Binding
 }
 
@@ -12,6 +14,16 @@ var GameOverKeys = gameOverKeyMap{
 		key.WithKeys("enter"),
 		key.WithHelp("↵", "restart"),
 	),
+	// Only enabled after clearing a campaign stage that isn't the last one
+	Next: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next stage"),
+		key.WithDisabled(),
+	),
+	Title: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "title screen"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q"),
 		key.WithHelp("q", "quit"),
@@ -19,9 +31,9 @@ var GameOverKeys = gameOverKeyMap{
 }
 
 func (k gameOverKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Restart, k.Quit}
+	return []key.Binding{k.Restart, k.Next, k.Title, k.Quit}
 }
 
 func (k gameOverKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Restart, k.Quit}}
+	return [][]key.Binding{{k.Restart, k.Next, k.Title, k.Quit}}
 }
@@ -0,0 +1,35 @@
+package key_maps
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"strings"
+)
+
+type initialsKeyMap struct {
+	Letter  key.Binding
+	Delete  key.Binding
+	Confirm key.Binding
+}
+
+var InitialsKeys = initialsKeyMap{
+	Letter: key.NewBinding(
+		key.WithKeys(strings.Split("abcdefghijklmnopqrstuvwxyz", "")...),
+		key.WithHelp("a-z", "type initials"),
+	),
+	Delete: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("⌫", "delete"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("↵", "confirm"),
+	),
+}
+
+func (k initialsKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Letter, k.Delete, k.Confirm}
+}
+
+func (k initialsKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Letter, k.Delete, k.Confirm}}
+}
@@ -0,0 +1,23 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+// Keys that work anywhere in kiosk mode; these aren't shown in the help
+type kioskKeyMap struct {
+	AdminQuit key.Binding
+}
+
+var KioskKeys = kioskKeyMap{
+	AdminQuit: key.NewBinding(
+		key.WithKeys("ctrl+q"),
+		key.WithHelp("ctrl+q", "quit (admin)"),
+	),
+}
+
+func (k kioskKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.AdminQuit}
+}
+
+func (k kioskKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.AdminQuit}}
+}
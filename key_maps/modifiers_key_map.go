@@ -0,0 +1,37 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type modifiersKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Toggle key.Binding
+	Back   key.Binding
+}
+
+var ModifiersKeys = modifiersKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("␣", "toggle"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("enter", "esc"),
+		key.WithHelp("↵/esc", "back"),
+	),
+}
+
+func (k modifiersKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Toggle, k.Back}
+}
+
+func (k modifiersKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Toggle, k.Back}}
+}
@@ -0,0 +1,32 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type perkChoiceKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Choose key.Binding
+}
+
+var PerkChoiceKeys = perkChoiceKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+	),
+	Choose: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("↵/␣", "choose"),
+	),
+}
+
+func (k perkChoiceKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Choose}
+}
+
+func (k perkChoiceKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Choose}}
+}
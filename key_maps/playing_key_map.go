@@ -3,10 +3,16 @@ package key_maps
 import "github.com/charmbracelet/bubbles/key"
 
 type playingKeyMap struct {
-	Left  key.Binding
-	Right key.Binding
-	Shoot key.Binding
-	Pause key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Shoot  key.Binding
+	Bomb   key.Binding
+	Shield key.Binding
+	Dash   key.Binding
+	Pause  key.Binding
+	Debug  key.Binding
 }
 
 var PlayingKeys = playingKeyMap{
@@ -18,20 +24,47 @@ var PlayingKeys = playingKeyMap{
 		key.WithKeys("right", "d"),
 		key.WithHelp("→/d", "right"),
 	),
+	// Up and down are only enabled in free movement mode
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+		key.WithDisabled(),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+		key.WithDisabled(),
+	),
 	Shoot: key.NewBinding(
 		key.WithKeys(" "),
 		key.WithHelp("␣", "shoot"),
 	),
+	Bomb: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "smart bomb"),
+	),
+	Shield: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "shield"),
+	),
+	Dash: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "dash"),
+	),
 	Pause: key.NewBinding(
 		key.WithKeys("p"),
 		key.WithHelp("p", "pause"),
 	),
+	Debug: key.NewBinding(
+		key.WithKeys("f3"),
+		key.WithHelp("f3", "debug overlay"),
+	),
 }
 
 func (k playingKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Left, k.Right, k.Shoot, k.Pause}
+	return []key.Binding{k.Left, k.Right, k.Up, k.Down, k.Shoot, k.Bomb, k.Shield, k.Dash, k.Pause}
 }
 
 func (k playingKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Left, k.Right, k.Shoot, k.Pause}}
+	return [][]key.Binding{{k.Left, k.Right, k.Up, k.Down, k.Shoot, k.Bomb, k.Shield, k.Dash, k.Pause, k.Debug}}
 }
@@ -0,0 +1,37 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type shopKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Buy  key.Binding
+	Done key.Binding
+}
+
+var ShopKeys = shopKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+	),
+	Buy: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("␣", "buy"),
+	),
+	Done: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("↵", "next wave"),
+	),
+}
+
+func (k shopKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Buy, k.Done}
+}
+
+func (k shopKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down, k.Buy, k.Done}}
+}
@@ -0,0 +1,27 @@
+package key_maps
+
+import "github.com/charmbracelet/bubbles/key"
+
+type stageIntroKeyMap struct {
+	Start key.Binding
+	Back  key.Binding
+}
+
+var StageIntroKeys = stageIntroKeyMap{
+	Start: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("↵", "start"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "back"),
+	),
+}
+
+func (k stageIntroKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Start, k.Back}
+}
+
+func (k stageIntroKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Start, k.Back}}
+}
@@ -3,15 +3,30 @@ package key_maps
 import "github.com/charmbracelet/bubbles/key"
 
 type titleViewKeyMap struct {
-	Start key.Binding
-	Quit  key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Start     key.Binding
+	Modifiers key.Binding
+	Quit      key.Binding
 }
 
 var TitleViewKeys = titleViewKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "w"),
+		key.WithHelp("↑/w", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "s"),
+		key.WithHelp("↓/s", "down"),
+	),
 	Start: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("↵", "start"),
 	),
+	Modifiers: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "modifiers"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q"),
 		key.WithHelp("q", "quit"),
@@ -19,9 +34,9 @@ var TitleViewKeys = titleViewKeyMap{
 }
 
 func (k titleViewKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Start, k.Quit}
+	return []key.Binding{k.Up, k.Down, k.Start, k.Modifiers, k.Quit}
 }
 
 func (k titleViewKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Start, k.Quit}}
+	return [][]key.Binding{{k.Up, k.Down, k.Start, k.Modifiers, k.Quit}}
 }
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"retro-shooter-game/key_maps"
+	"strings"
+	"time"
+)
+
+// Kiosk mode, for running the game unattended on a shared terminal
+// When nobody's playing, it cycles through an attract loop: the title screen, a demo game then the high scores
+
+const attractTitleDuration = 20 * time.Second // Idle time on the title screen before the demo starts
+const attractDemoDuration = 30 * time.Second
+const attractHighScoresDuration = 10 * time.Second
+const gameOverReturnDelay = 10 * time.Second // Idle time on the game over screen before returning to the title screen
+const kioskIdleTimeout = 60 * time.Second    // Idle time anywhere else before returning to the title screen
+const initialsLength = 3
+const demoDodgeDistance = 3 // The demo player dodges enemy bullets up to this many rows above the ship
+
+// Disable every way of quitting except the admin key combo
+func applyKioskKeyBindings() {
+	key_maps.TitleViewKeys.Quit.SetEnabled(false)
+	key_maps.PauseKeys.Quit.SetEnabled(false)
+	key_maps.GameOverKeys.Quit.SetEnabled(false)
+}
+
+// Move through the attract loop, and back to the title screen when players walk away
+func (m model) updateKiosk() (tea.Model, tea.Cmd) {
+	idleDuration := time.Since(m.lastActivity)
+	switch v := m.view.(type) {
+	case titleView:
+		if idleDuration >= attractTitleDuration {
+			return startDemo(m)
+		}
+	case highScoresView:
+		if time.Since(v.startedAt) >= attractHighScoresDuration {
+			m.view = newTitleView()
+			m.lastActivity = time.Now()
+		}
+	case *gameView:
+		switch {
+		case v.config.demo:
+			if v.clock.now() >= attractDemoDuration || v.isGameOver() {
+				m.view = newHighScoresView()
+			}
+		case v.enteringInitials:
+			if idleDuration >= kioskIdleTimeout {
+				v.initials = fmt.Sprintf("%-*s", initialsLength, v.initials)
+				v.confirmInitials()
+				m.view = newTitleView()
+			}
+		case v.isGameOver():
+			if idleDuration >= gameOverReturnDelay {
+				m.view = newTitleView()
+			}
+		case idleDuration >= kioskIdleTimeout:
+			m.view = newTitleView()
+		}
+	default:
+		if idleDuration >= kioskIdleTimeout {
+			m.view = newTitleView()
+		}
+	}
+	return m, nil
+}
+
+// Start a game played by the computer, which any key press interrupts
+func startDemo(m model) (tea.Model, tea.Cmd) {
+	config := m.config
+	config.mode = classicMode
+	config.modifiers = 0
+	config.credits = 0
+	config.ship = falconShip
+	config.demo = true
+	return startGame(m, config)
+}
+
+// Move and shoot for the demo player: dodge bullets about to hit the ship, otherwise head for the nearest enemy and shoot
+func (gv *gameView) updateDemoPlayer() tea.Cmd {
+	x := gv.playerPosition.x
+	if gv.isColumnThreatened(x) {
+		for _, dx := range []int{-1, 1} {
+			if isPositionValid(vector2d{x: x + dx, y: gv.playerPosition.y}) && !gv.isColumnThreatened(x+dx) {
+				return gv.movePlayer(vector2d{x: dx, y: 0})
+			}
+		}
+		return nil
+	}
+
+	targetX, found := gv.nearestEnemyColumn()
+	if !found {
+		return nil
+	}
+	if targetX == x {
+		if !gv.isBulletCooldownActive() {
+			return gv.shoot()
+		}
+		return nil
+	}
+
+	dx := 1
+	if targetX < x {
+		dx = -1
+	}
+	if gv.isColumnThreatened(x + dx) {
+		return nil
+	}
+	return gv.movePlayer(vector2d{x: dx, y: 0})
+}
+
+// Whether an enemy bullet is about to land on the player's row in the given column, or a laser is aimed at it
+func (gv *gameView) isColumnThreatened(x int) bool {
+	for _, b := range gv.enemyBullets {
+		if b.position.x != x {
+			continue
+		}
+		if b.kind == laserProjectile || (b.position.y <= gv.playerPosition.y && b.position.y >= gv.playerPosition.y-demoDodgeDistance) {
+			return true
+		}
+	}
+	return false
+}
+
+func (gv *gameView) nearestEnemyColumn() (x int, found bool) {
+	for _, position := range gv.enemyPositions.toSlice() {
+		if !found || abs(position.x-gv.playerPosition.x) < abs(x-gv.playerPosition.x) {
+			x = position.x
+			found = true
+		}
+	}
+	return
+}
+
+func (gv *gameView) updateInitialsEntry(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, key_maps.InitialsKeys.Confirm):
+		if len(gv.initials) == initialsLength {
+			gv.confirmInitials()
+		}
+	case key.Matches(msg, key_maps.InitialsKeys.Delete):
+		if len(gv.initials) > 0 {
+			gv.initials = gv.initials[:len(gv.initials)-1]
+		}
+	case key.Matches(msg, key_maps.InitialsKeys.Letter):
+		if len(gv.initials) < initialsLength {
+			gv.initials += strings.ToUpper(msg.String())
+		}
+	}
+}
+
+// Save the initials with the high score, which was recorded without them when the game ended
+func (gv *gameView) confirmInitials() {
+	gv.enteringInitials = false
+	category := gv.leaderboardCategory()
+	gv.leaderboard[category][gv.highScoreIndex].Initials = gv.initials
+	gv.leaderboardScores = gv.leaderboard[category]
+	gv.leaderboardError = gv.leaderboard.save()
+}
+
+func (gv *gameView) getInitialsEntryString() string {
+	initials := gv.initials + strings.Repeat("_", initialsLength-len(gv.initials))
+	return lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render("New high score! Enter your initials: " + strings.Join(strings.Split(initials, ""), " "))
+}
+
+// High scores for every game mode, shown as part of the attract loop
+type highScoresView struct {
+	startedAt   time.Time
+	leaderboard leaderboard
+	err         error
+}
+
+func newHighScoresView() highScoresView {
+	lb, err := loadLeaderboard()
+	return highScoresView{startedAt: time.Now(), leaderboard: lb, err: err}
+}
+
+func (hsv highScoresView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.view = newTitleView()
+	}
+	return m, nil
+}
+
+func (hsv highScoresView) draw(m model) string {
+	if hsv.err != nil {
+		return fmt.Sprintf("Couldn't load high scores: %v", hsv.err)
+	}
+
+	columns := make([]string, 0, len(gameModes))
+	for _, mode := range gameModes {
+		scores := hsv.leaderboard[mode.settings().leaderboardCategory]
+		columns = append(columns, lipgloss.NewStyle().PaddingRight(4).PaddingBottom(1).Render(drawLeaderboard(mode.String(), scores, -1)))
+	}
+
+	// Two columns of leaderboards, so they all fit on screen
+	rows := make([]string, 0, (len(columns)+1)/2)
+	for i := 0; i < len(columns); i += 2 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, columns[i:min(i+2, len(columns))]...))
+	}
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().Foreground(accentColor).Bold(true).PaddingBottom(1).Render("High Scores"),
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		secondaryTextStyle.Render("Press any key"),
+	)
+}
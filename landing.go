@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const formationPushBackRows = 3 // Must be odd so each row keeps moving in the right direction (as if the formation had moved down)
+const invasionMeterCapacity = 10
+
+// What happens when the enemy formation reaches the bottom of the screen
+type landingRule int
+
+const (
+	modeLandingRule landingRule = iota // Use the game mode's landing rule
+	instantGameOver
+	loseLifeAndPushBack
+	invasionMeter
+)
+
+var landingRuleNames = map[landingRule]string{
+	modeLandingRule:     "mode",
+	instantGameOver:     "classic",
+	loseLifeAndPushBack: "push-back",
+	invasionMeter:       "invasion",
+}
+
+func (lr landingRule) String() string {
+	return landingRuleNames[lr]
+}
+
+// Implements `flag.Value` so the landing rule can be set from the command line
+func (lr *landingRule) Set(s string) error {
+	for rule, name := range landingRuleNames {
+		if name == s {
+			*lr = rule
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown landing rule %q", s)
+}
+
+func (gv *gameView) handleFormationLanded() {
+	switch gv.landingRule {
+	case instantGameOver:
+		gv.loseGame()
+	case loseLifeAndPushBack:
+		gv.loseLife()
+		gv.moveFormationUp(formationPushBackRows)
+	case invasionMeter:
+		// Enemies in the bottom row land, filling up the meter, then the rest of the formation carries on moving down
+		bottomY := gv.enemyPositions.maxY()
+		gv.invasionCount += len(gv.enemyPositions[bottomY])
+		delete(gv.enemyPositions, bottomY)
+
+		if gv.invasionCount >= invasionMeterCapacity {
+			gv.loseGame()
+		} else if gv.enemyPositions.count() == 0 {
+			gv.checkWaveCleared()
+		} else {
+			gv.moveFormationDown()
+		}
+	}
+}
+
+func (gv *gameView) moveFormationUp(rows int) {
+	updatedEnemyPositions := make(vector2dMap[enemy], len(gv.enemyPositions))
+	for y, xMap := range gv.enemyPositions {
+		updatedEnemyPositions[y-rows] = xMap
+	}
+	gv.enemyYOffset -= rows
+	gv.enemyPositions = updatedEnemyPositions
+}
+
+func (gv *gameView) getInvasionMeterString() string {
+	filledCount := min(gv.invasionCount, invasionMeterCapacity)
+	return fmt.Sprintf("Invasion: [%s%s]", strings.Repeat("#", filledCount), strings.Repeat(" ", invasionMeterCapacity-filledCount))
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"slices"
+	"strings"
+	"time"
+)
+
+const leaderboardFileName = "high_scores.json"
+const leaderboardSize = 10
+const leaderboardDisplaySize = 5
+
+type highScore struct {
+	Score int       `json:"score"`
+	Wave  int       `json:"wave"`
+	Date  time.Time `json:"date"`
+	// Names of modifiers the score was achieved with
+	Modifiers []string `json:"modifiers,omitempty"`
+	// Number of credits used, including the first; zero for scores recorded before continues were added
+	Credits int `json:"credits,omitempty"`
+	// Entered by the player in kiosk mode
+	Initials string `json:"initials,omitempty"`
+	// ID of the ship flown
+	Ship string `json:"ship,omitempty"`
+}
+
+// High scores for each category (e.g. game mode), highest first
+type leaderboard map[string][]highScore
+
+func loadLeaderboard() (leaderboard, error) {
+	lb := make(leaderboard)
+	err := readDataFile(leaderboardFileName, &lb)
+	return lb, err
+}
+
+func (lb leaderboard) save() error {
+	return writeDataFile(leaderboardFileName, lb)
+}
+
+// Add a high score to a category; returns its position in the category, or -1 if it didn't make the leaderboard
+func (lb leaderboard) add(category string, hs highScore) int {
+	scores := lb[category]
+
+	// Insert after any equal scores, so earlier scores keep their position
+	index, _ := slices.BinarySearchFunc(scores, hs.Score, func(existing highScore, score int) int {
+		if existing.Score >= score {
+			return -1
+		}
+		return 1
+	})
+	if index >= leaderboardSize {
+		return -1
+	}
+
+	scores = slices.Insert(scores, index, hs)
+	if len(scores) > leaderboardSize {
+		scores = scores[:leaderboardSize]
+	}
+	lb[category] = scores
+	return index
+}
+
+// Draw the top scores in a category, highlighting the score at `highlightedIndex` (use -1 for none)
+func drawLeaderboard(title string, scores []highScore, highlightedIndex int) string {
+	var sb strings.Builder
+	sb.WriteString(title)
+	if len(scores) == 0 {
+		sb.WriteString("\n")
+		sb.WriteString(secondaryTextStyle.Render("No high scores yet"))
+	}
+
+	// Only make room for initials if any of the scores shown have them
+	displayedScores := scores[:min(len(scores), leaderboardDisplaySize)]
+	hasInitials := slices.ContainsFunc(displayedScores, func(hs highScore) bool { return hs.Initials != "" })
+
+	for i, hs := range displayedScores {
+		line := fmt.Sprintf("%2d. %6d  wave %-3d %s", i+1, hs.Score, hs.Wave, hs.Date.Format(time.DateOnly))
+		if hasInitials {
+			line = fmt.Sprintf("%2d. %-*s %6d  wave %-3d %s", i+1, initialsLength, hs.Initials, hs.Score, hs.Wave, hs.Date.Format(time.DateOnly))
+		}
+		switch {
+		case hs.Credits == 1:
+			line += "  1CC"
+		case hs.Credits > 1:
+			line += fmt.Sprintf("  %d credits", hs.Credits)
+		}
+		if ship, ok := shipByID(hs.Ship); ok {
+			line += "  " + ship.String()
+		}
+		if len(hs.Modifiers) > 0 {
+			line += fmt.Sprintf("  [%s]", strings.Join(hs.Modifiers, ", "))
+		}
+		if i == highlightedIndex {
+			line = lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render(line)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
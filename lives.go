@@ -0,0 +1,116 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"time"
+)
+
+const lifeLostDuration = 3 * time.Second // How long the game stops for after losing a life
+const playerBlinkInterval = 250 * time.Millisecond
+const respawnBulletClearRadius = 6 // Enemy bullets within this many cells of the ship are cleared when it respawns
+
+type livesSettings struct {
+	startingLives int
+	firstScore    int // Score at which the first extra life is awarded
+	scoreInterval int // Score between subsequent extra lives
+	maxLives      int // Extra lives aren't awarded beyond this many lives
+}
+
+var defaultLivesSettings = livesSettings{
+	startingLives: 3,
+	firstScore:    10000,
+	scoreInterval: 20000,
+	maxLives:      5,
+}
+
+// Tracks the player's remaining lives, including awarding extra lives and the sequence played after losing a life
+type lives struct {
+	remaining          int
+	settings           livesSettings
+	nextExtraLifeScore int
+	// Timers, as times on the game clock
+	lifeLostTime      time.Duration
+	invulnerableUntil time.Duration
+	// Set when an extra life is awarded, until the notification has been started
+	extraLifePending        bool
+	displayExtraLifeMessage bool
+}
+
+func newLives(settings livesSettings) lives {
+	return lives{
+		remaining:          settings.startingLives,
+		settings:           settings,
+		nextExtraLifeScore: settings.firstScore,
+	}
+}
+
+// Award an extra life for each score threshold passed, up to the maximum number of lives
+// Thresholds passed while at the maximum are still used up, so the next extra life is at the next threshold
+// Extra lives are disabled if the score interval is zero
+func (l *lives) updateScore(score int) {
+	if l.settings.scoreInterval <= 0 {
+		return
+	}
+
+	for score >= l.nextExtraLifeScore {
+		if !l.isFull() {
+			l.remaining++
+			l.extraLifePending = true
+		}
+		l.nextExtraLifeScore += l.settings.scoreInterval
+	}
+}
+
+func (l *lives) isFull() bool {
+	return l.remaining >= l.settings.maxLives
+}
+
+// Start the notification for any extra lives awarded since this was last called
+func (l *lives) extraLifeCmd() tea.Cmd {
+	if !l.extraLifePending {
+		return nil
+	}
+
+	l.extraLifePending = false
+	l.displayExtraLifeMessage = true
+	return tea.Batch(extraLifeMessageTickCmd(), bellCmd())
+}
+
+func (l *lives) lose(now time.Duration) {
+	l.remaining--
+	l.lifeLostTime = now
+}
+
+func (l *lives) isGameOver() bool {
+	return l.remaining <= 0
+}
+
+func (l *lives) isLifeLostSequenceFinished(now time.Duration) bool {
+	return now-l.lifeLostTime >= lifeLostDuration
+}
+
+// Start the invulnerability window after the player respawns
+func (l *lives) respawn(now time.Duration, invulnerabilityDuration time.Duration) {
+	l.invulnerableUntil = now + invulnerabilityDuration
+}
+
+// Make the player invulnerable for at least the given duration, without cutting short any existing invulnerability
+func (l *lives) extendInvulnerability(now time.Duration, d time.Duration) {
+	l.invulnerableUntil = max(l.invulnerableUntil, now+d)
+}
+
+func (l *lives) isInvulnerable(now time.Duration) bool {
+	return now < l.invulnerableUntil
+}
+
+// Whether the player should be drawn at the given time, so it blinks during the life lost sequence and while invulnerable
+func (l *lives) isPlayerVisible(now time.Duration, status status) bool {
+	switch {
+	case status == lifeLost:
+		return ((now-l.lifeLostTime)/playerBlinkInterval)%2 == 0
+	case l.isInvulnerable(now):
+		return (now/playerBlinkInterval)%2 == 0
+	default:
+		return true
+	}
+}
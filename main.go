@@ -1,11 +1,14 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"os"
+	"retro-shooter-game/key_maps"
 	"strings"
 	"time"
 )
@@ -20,12 +23,19 @@ type model struct {
 	windowSize vector2d
 	view       view
 	help       help.Model
+	config     gameConfig
+	// Time of the last key press, or of returning to the title screen in the attract loop; used in kiosk mode
+	lastActivity time.Time
 }
 
 type enemyTickMsg time.Time
 type bulletTickMsg time.Time
 type lifeLostTickMsg time.Time
 type messageTickMsg time.Time
+type extraLifeMessageTickMsg time.Time
+type waveClearedTickMsg time.Time
+type continueTickMsg time.Time
+type kioskTickMsg time.Time
 
 var emptyVector2d = vector2d{x: -1, y: -1}
 
@@ -42,20 +52,27 @@ var accentColor = lipgloss.AdaptiveColor{
 	Dark:  "4",
 }
 var secondaryTextStyle = help.New().Styles.ShortDesc
+var selectedMenuItemStyle = lipgloss.NewStyle().Foreground(accentColor).Bold(true)
+var extraLifeStyle = lipgloss.NewStyle().Foreground(accentColor).Bold(true).Blink(true)
 
-func initialModel() model {
+func initialModel(config gameConfig) model {
 	return model{
-		view: newTitleView(),
-		help: help.New(),
+		view:         newTitleView(),
+		help:         help.New(),
+		config:       config,
+		lastActivity: time.Now(),
 	}
 }
 
 func (m model) Init() tea.Cmd {
+	if m.config.kiosk {
+		return kioskTickCmd()
+	}
 	return nil
 }
 
-func enemyTickCmd() tea.Cmd {
-	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
+func enemyTickCmd(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
 		return enemyTickMsg(t)
 	})
 }
@@ -67,7 +84,7 @@ func bulletTickCmd() tea.Cmd {
 }
 
 func lifeLostTickCmd() tea.Cmd {
-	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
+	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
 		return lifeLostTickMsg(t)
 	})
 }
@@ -78,13 +95,52 @@ func messageTickCmd() tea.Cmd {
 	})
 }
 
+func waveClearedTickCmd() tea.Cmd {
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return waveClearedTickMsg(t)
+	})
+}
+
+func continueTickCmd() tea.Cmd {
+	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
+		return continueTickMsg(t)
+	})
+}
+
+func kioskTickCmd() tea.Cmd {
+	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
+		return kioskTickMsg(t)
+	})
+}
+
+func extraLifeMessageTickCmd() tea.Cmd {
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return extraLifeMessageTickMsg(t)
+	})
+}
+
+// Ring the terminal bell
+func bellCmd() tea.Cmd {
+	return func() tea.Msg {
+		fmt.Print("\a")
+		return nil
+	}
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.windowSize = vector2d{x: msg.Width, y: msg.Height}
 	case tea.KeyMsg:
+		if m.config.kiosk && key.Matches(msg, key_maps.KioskKeys.AdminQuit) {
+			return m, tea.Quit
+		}
+		m.lastActivity = time.Now()
 		return m.view.update(msg, m)
-	case enemyTickMsg, bulletTickMsg, lifeLostTickMsg, messageTickMsg:
+	case kioskTickMsg:
+		updatedModel, cmd := m.updateKiosk()
+		return updatedModel, tea.Batch(cmd, kioskTickCmd())
+	case enemyTickMsg, bulletTickMsg, lifeLostTickMsg, messageTickMsg, extraLifeMessageTickMsg, waveClearedTickMsg, continueTickMsg:
 		return m.view.update(msg, m)
 	}
 
@@ -110,7 +166,17 @@ func drawTitleBar(m model, title string) string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
+	config := registerFlags()
+	flag.Parse()
+	if err := config.validate(); err != nil {
+		fmt.Printf("Error: %v", err)
+		os.Exit(2)
+	}
+	if config.kiosk {
+		applyKioskKeyBindings()
+	}
+
+	p := tea.NewProgram(initialModel(*config))
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
 		os.Exit(1)
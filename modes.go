@@ -0,0 +1,73 @@
+package main
+
+import "time"
+
+type gameMode int
+
+const (
+	classicMode gameMode = iota
+	endlessMode
+	timeAttackMode
+	scrollingMode
+)
+
+var gameModes = []gameMode{classicMode, endlessMode, timeAttackMode, scrollingMode}
+
+type gameModeSettings struct {
+	name                string
+	description         string
+	leaderboardCategory string
+	landingRule         landingRule
+	multipleWaves       bool          // Whether a new wave starts once all enemies are destroyed, rather than the game being won
+	timeLimit           time.Duration // Zero if there's no time limit
+	stage               stageType     // Type of the first stage
+}
+
+var gameModeSettingsMap = map[gameMode]gameModeSettings{
+	classicMode: {
+		name:                "Classic",
+		description:         "Destroy all the enemies before they reach the bottom",
+		leaderboardCategory: "classic",
+		landingRule:         instantGameOver,
+	},
+	endlessMode: {
+		name:                "Endless Survival",
+		description:         "Survive as many waves as possible",
+		leaderboardCategory: "endless",
+		landingRule:         loseLifeAndPushBack,
+		multipleWaves:       true,
+	},
+	timeAttackMode: {
+		name:                "Time Attack",
+		description:         "Clear as many waves as possible in 3 minutes",
+		leaderboardCategory: "time-attack",
+		landingRule:         invasionMeter,
+		multipleWaves:       true,
+		timeLimit:           3 * time.Minute,
+	},
+	scrollingMode: {
+		name:                "Scrolling Shooter",
+		description:         "Fly through a scrolling level and defeat the boss at the end",
+		leaderboardCategory: "scrolling",
+		landingRule:         instantGameOver,
+		stage:               scrollingStage,
+	},
+}
+
+func (gm gameMode) settings() gameModeSettings {
+	return gameModeSettingsMap[gm]
+}
+
+func (gm gameMode) String() string {
+	return gm.settings().name
+}
+
+// Waves get harder in modes with multiple waves; enemies move faster...
+func enemyTickInterval(wave int) time.Duration {
+	return max(500*time.Millisecond-time.Duration(wave-1)*40*time.Millisecond, 150*time.Millisecond)
+}
+
+// ...and shoot more often
+func enemyFireChancePercent(wave int) int {
+	return min(33+(wave-1)*5, 80)
+}
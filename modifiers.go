@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const invisibleEnemyBulletRange = 4 // Enemy bullets are only visible within this many rows of the player, with the invisible bullets modifier
+const scorePerBulletDodged = 25     // With the pacifist modifier
+
+// Modifiers change the rules of a game, in exchange for a score multiplier
+type modifier int
+
+const (
+	hardcoreModifier modifier = iota
+	doubleSpeedEnemiesModifier
+	mirroredControlsModifier
+	invisibleEnemyBulletsModifier
+	rapidFireModifier
+	pacifistModifier
+)
+
+var modifiers = []modifier{
+	hardcoreModifier,
+	doubleSpeedEnemiesModifier,
+	mirroredControlsModifier,
+	invisibleEnemyBulletsModifier,
+	rapidFireModifier,
+	pacifistModifier,
+}
+
+type modifierSettings struct {
+	name            string
+	description     string
+	scoreMultiplier float64
+}
+
+var modifierSettingsMap = map[modifier]modifierSettings{
+	hardcoreModifier: {
+		name:            "Hardcore",
+		description:     "One life and no extra lives",
+		scoreMultiplier: 2,
+	},
+	doubleSpeedEnemiesModifier: {
+		name:            "Double-speed enemies",
+		description:     "Enemies move twice as fast",
+		scoreMultiplier: 1.5,
+	},
+	mirroredControlsModifier: {
+		name:            "Mirrored controls",
+		description:     "Left is right and up is down",
+		scoreMultiplier: 1.25,
+	},
+	invisibleEnemyBulletsModifier: {
+		name:            "Invisible bullets",
+		description:     "Enemy bullets can't be seen until they're close",
+		scoreMultiplier: 1.5,
+	},
+	rapidFireModifier: {
+		name:            "Rapid fire",
+		description:     "No cooldown on shooting, but enemies shoot twice as often",
+		scoreMultiplier: 1.25,
+	},
+	pacifistModifier: {
+		name:            "Pacifist",
+		description:     "No shooting; score by dodging enemy bullets",
+		scoreMultiplier: 1,
+	},
+}
+
+func (md modifier) settings() modifierSettings {
+	return modifierSettingsMap[md]
+}
+
+func (md modifier) String() string {
+	return md.settings().name
+}
+
+// Set of enabled modifiers, one bit per modifier
+type modifierSet uint
+
+func (ms modifierSet) has(md modifier) bool {
+	return ms&(1<<md) != 0
+}
+
+func (ms modifierSet) toggle(md modifier) modifierSet {
+	return ms ^ (1 << md)
+}
+
+func (ms modifierSet) scoreMultiplier() float64 {
+	multiplier := 1.0
+	for _, md := range modifiers {
+		if ms.has(md) {
+			multiplier *= md.settings().scoreMultiplier
+		}
+	}
+	return multiplier
+}
+
+func (ms modifierSet) names() []string {
+	names := make([]string, 0)
+	for _, md := range modifiers {
+		if ms.has(md) {
+			names = append(names, md.String())
+		}
+	}
+	return names
+}
+
+func (ms modifierSet) String() string {
+	if ms == 0 {
+		return "None"
+	}
+	return fmt.Sprintf("%s (x%.2g score)", strings.Join(ms.names(), ", "), ms.scoreMultiplier())
+}
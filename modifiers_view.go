@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"retro-shooter-game/key_maps"
+	"strings"
+)
+
+// For choosing the modifiers applied to games started from the title screen
+type modifiersView struct {
+	titleView         titleView // For returning to the title screen as it was
+	selectedItemIndex int
+}
+
+func (mv modifiersView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key_maps.ModifiersKeys.Up):
+			mv.selectedItemIndex = (mv.selectedItemIndex - 1 + len(modifiers)) % len(modifiers)
+			m.view = mv
+		case key.Matches(msg, key_maps.ModifiersKeys.Down):
+			mv.selectedItemIndex = (mv.selectedItemIndex + 1) % len(modifiers)
+			m.view = mv
+		case key.Matches(msg, key_maps.ModifiersKeys.Toggle):
+			m.config.modifiers = m.config.modifiers.toggle(modifiers[mv.selectedItemIndex])
+		case key.Matches(msg, key_maps.ModifiersKeys.Back):
+			m.view = mv.titleView
+		}
+	}
+	return m, nil
+}
+
+func (mv modifiersView) draw(m model) string {
+	var sb strings.Builder
+	for i, md := range modifiers {
+		checkbox := "[ ]"
+		if m.config.modifiers.has(md) {
+			checkbox = "[x]"
+		}
+
+		line := fmt.Sprintf("%s %s (x%.2g)", checkbox, md, md.settings().scoreMultiplier)
+		sb.WriteString(drawMenuItem(line, i == mv.selectedItemIndex, false))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(secondaryTextStyle.Render(modifiers[mv.selectedItemIndex].settings().description))
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().PaddingBottom(1).Render("Modifiers"),
+		lipgloss.NewStyle().PaddingBottom(1).Render(sb.String()),
+		lipgloss.NewStyle().PaddingBottom(1).Render(fmt.Sprintf("Score multiplier: x%.2g", m.config.modifiers.scoreMultiplier())),
+		m.help.View(key_maps.ModifiersKeys),
+	)
+}
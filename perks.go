@@ -0,0 +1,266 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/lipgloss"
+	"slices"
+	"strings"
+)
+
+const perkChoiceCount = 3
+const perkSynergyWeightMultiplier = 3 // Perks that combine well with one already chosen are this much more likely to be offered
+const extraLifePerkScorePercent = 75  // Score kept when taking the extra life perk
+
+type perkRarity int
+
+const (
+	commonPerk perkRarity = iota
+	rarePerk
+	epicPerk
+)
+
+var perkRarityWeights = map[perkRarity]int{commonPerk: 60, rarePerk: 30, epicPerk: 10}
+
+func (r perkRarity) String() string {
+	return [...]string{"Common", "Rare", "Epic"}[r]
+}
+
+func (r perkRarity) colour() lipgloss.TerminalColor {
+	return [...]lipgloss.TerminalColor{lipgloss.NoColor{}, lipgloss.Color("12"), lipgloss.Color("13")}[r]
+}
+
+// Perks are chosen between waves and last for the rest of the run
+type perk int
+
+const (
+	extraBulletPerk perk = iota
+	piercingShotsPerk
+	fasterCooldownPerk
+	largerBurstPerk
+	slowerEnemyBulletsPerk
+	fasterMovementPerk
+	quickShieldPerk
+	extraLifePerk
+)
+
+var perks = []perk{
+	extraBulletPerk,
+	piercingShotsPerk,
+	fasterCooldownPerk,
+	largerBurstPerk,
+	slowerEnemyBulletsPerk,
+	fasterMovementPerk,
+	quickShieldPerk,
+	extraLifePerk,
+}
+
+type perkSettings struct {
+	name        string
+	description string
+	rarity      perkRarity
+	maxCount    int                     // Maximum number of times the perk can be chosen per run
+	synergies   []perk                  // Having any of these makes the perk more likely to be offered
+	needsGuns   bool                    // Not offered with the pacifist modifier
+	isAvailable func(gv *gameView) bool // Perks are only offered while this returns true, if set
+	apply       func(gv *gameView)
+}
+
+var perkSettingsMap = map[perk]perkSettings{
+	extraBulletPerk: {
+		name:        "Spread shot",
+		description: "+1 bullet per shot",
+		rarity:      rarePerk,
+		maxCount:    2,
+		synergies:   []perk{piercingShotsPerk, fasterCooldownPerk},
+		needsGuns:   true,
+		apply:       func(gv *gameView) { gv.stats.bulletsPerShot++ },
+	},
+	piercingShotsPerk: {
+		name:        "Piercing rounds",
+		description: "Bullets carry on through enemies",
+		rarity:      epicPerk,
+		maxCount:    1,
+		synergies:   []perk{extraBulletPerk},
+		needsGuns:   true,
+		isAvailable: func(gv *gameView) bool { return !gv.stats.piercingShots },
+		apply:       func(gv *gameView) { gv.stats.piercingShots = true },
+	},
+	fasterCooldownPerk: {
+		name:        "Heat sinks",
+		description: "Shooting cooldown -20%",
+		rarity:      commonPerk,
+		maxCount:    3,
+		synergies:   []perk{largerBurstPerk, extraBulletPerk},
+		needsGuns:   true,
+		apply:       func(gv *gameView) { gv.stats.bulletCooldownDuration = gv.stats.bulletCooldownDuration * 4 / 5 },
+	},
+	largerBurstPerk: {
+		name:        "Bigger magazine",
+		description: "+2 bullets before the cooldown starts",
+		rarity:      commonPerk,
+		maxCount:    3,
+		synergies:   []perk{fasterCooldownPerk},
+		needsGuns:   true,
+		apply:       func(gv *gameView) { gv.stats.bulletCooldownMaxCount += 2 },
+	},
+	slowerEnemyBulletsPerk: {
+		name:        "Time dilation",
+		description: "Enemy bullets move at half speed",
+		rarity:      rarePerk,
+		maxCount:    1,
+		synergies:   []perk{fasterMovementPerk},
+		apply:       func(gv *gameView) { gv.stats.enemyBulletMoveInterval = 2 },
+	},
+	fasterMovementPerk: {
+		name:        "Thrusters",
+		description: "+1 move speed",
+		rarity:      commonPerk,
+		maxCount:    2,
+		synergies:   []perk{slowerEnemyBulletsPerk},
+		apply:       func(gv *gameView) { gv.stats.moveStep++ },
+	},
+	quickShieldPerk: {
+		name:        "Capacitors",
+		description: "Shield and dash recharge 25% faster",
+		rarity:      rarePerk,
+		maxCount:    2,
+		synergies:   []perk{fasterMovementPerk},
+		apply: func(gv *gameView) {
+			gv.shield.cooldown = gv.shield.cooldown * 3 / 4
+			gv.dash.cooldown = gv.dash.cooldown * 3 / 4
+		},
+	},
+	extraLifePerk: {
+		name:        "Blood money",
+		description: fmt.Sprintf("+1 life, but lose %d%% of your score", 100-extraLifePerkScorePercent),
+		rarity:      commonPerk,
+		maxCount:    3,
+		isAvailable: func(gv *gameView) bool { return !gv.lives.isFull() },
+		apply: func(gv *gameView) {
+			gv.lives.remaining++
+			// Set directly rather than through addScore, so coins already earned are kept
+			gv.score = gv.score * extraLifePerkScorePercent / 100
+		},
+	},
+}
+
+func (p perk) settings() perkSettings {
+	return perkSettingsMap[p]
+}
+
+func (p perk) String() string {
+	return p.settings().name
+}
+
+func (gv *gameView) perkCount(p perk) int {
+	count := 0
+	for _, chosen := range gv.perks {
+		if chosen == p {
+			count++
+		}
+	}
+	return count
+}
+
+// Whether the perk combines well with one already chosen
+func (gv *gameView) hasPerkSynergy(p perk) bool {
+	for _, s := range p.settings().synergies {
+		if slices.Contains(gv.perks, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (gv *gameView) perkWeight(p perk) int {
+	s := p.settings()
+	if gv.perkCount(p) >= s.maxCount ||
+		(s.needsGuns && gv.config.modifiers.has(pacifistModifier)) ||
+		(s.isAvailable != nil && !s.isAvailable(gv)) {
+		return 0
+	}
+	weight := perkRarityWeights[s.rarity]
+	if gv.hasPerkSynergy(p) {
+		weight *= perkSynergyWeightMultiplier
+	}
+	return weight
+}
+
+// Draw up to three different perks from the pool, weighted by rarity and synergy
+func (gv *gameView) drawPerkChoices() []perk {
+	weights := make(map[perk]int, len(perks))
+	totalWeight := 0
+	for _, p := range perks {
+		weights[p] = gv.perkWeight(p)
+		totalWeight += weights[p]
+	}
+
+	var choices []perk
+	for len(choices) < perkChoiceCount && totalWeight > 0 {
+		n := gv.rng.IntN(totalWeight)
+		for _, p := range perks {
+			if n < weights[p] {
+				choices = append(choices, p)
+				totalWeight -= weights[p]
+				weights[p] = 0
+				break
+			}
+			n -= weights[p]
+		}
+	}
+	return choices
+}
+
+// Offer a choice of perks once a wave has been cleared, going straight to the shop if there's nothing left to offer
+func (gv *gameView) openPerkChoice() {
+	gv.perkChoices = gv.drawPerkChoices()
+	if len(gv.perkChoices) == 0 {
+		gv.openShop()
+		return
+	}
+	gv.status = choosingPerk
+	gv.selectedPerkIndex = 0
+	gv.clock.pause()
+}
+
+func (gv *gameView) movePerkSelection(offset int) {
+	gv.selectedPerkIndex = (gv.selectedPerkIndex + offset + len(gv.perkChoices)) % len(gv.perkChoices)
+}
+
+func (gv *gameView) chooseSelectedPerk() {
+	p := gv.perkChoices[gv.selectedPerkIndex]
+	p.settings().apply(gv)
+	gv.perks = append(gv.perks, p)
+	gv.perkChoices = nil
+	gv.openShop()
+}
+
+func (gv *gameView) getPerkChoiceString() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Wave %d cleared! Choose a perk\n", gv.wave))
+	for i, p := range gv.perkChoices {
+		s := p.settings()
+		line := fmt.Sprintf("%-16s %-40s", s.name, s.description)
+		rarity := lipgloss.NewStyle().Foreground(s.rarity.colour()).Render(s.rarity.String())
+		if gv.hasPerkSynergy(p) {
+			rarity += extraLifeStyle.Render(" (synergy)")
+		}
+		sb.WriteString(drawMenuItem(line, i == gv.selectedPerkIndex, false))
+		sb.WriteString(" " + rarity + "\n")
+	}
+	return sb.String()
+}
+
+// Chosen perks, with a count for perks taken more than once
+func (gv *gameView) getPerksString() string {
+	names := make([]string, 0, len(gv.perks))
+	for _, p := range perks {
+		switch count := gv.perkCount(p); {
+		case count == 1:
+			names = append(names, p.String())
+		case count > 1:
+			names = append(names, fmt.Sprintf("%s x%d", p, count))
+		}
+	}
+	return "Perks: " + strings.Join(names, ", ")
+}
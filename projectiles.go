@@ -0,0 +1,205 @@
+package main
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"math/rand/v2"
+)
+
+const scorePerBombHit = 100
+const bombFuseRows = 4           // Bombs explode into fragments once they're this many rows from the bottom
+const laserTelegraphTicks = 10   // Bullet ticks a laser is shown as a warning before it fires
+const laserFireTicks = 4         // Bullet ticks a laser stays firing for
+const fragmentDirectionCount = 3 // Fragments fly down-left, down and down-right
+
+type projectileKind int
+
+const (
+	plainBullet projectileKind = iota
+	boltProjectile
+	bombProjectile
+	fragmentProjectile
+	zigZagProjectile
+	laserProjectile
+)
+
+// What happens when a player bullet meets an enemy projectile
+type shotRule int
+
+const (
+	shotDown      shotRule = iota // Both are destroyed
+	absorbs                       // Only the player bullet is destroyed
+	passesThrough                 // Neither is affected
+)
+
+type projectileKindSettings struct {
+	glyph         rune
+	colour        lipgloss.TerminalColor
+	moveInterval  int // Moves once every this many enemy bullet updates
+	whenShot      shotRule
+	scoreWhenShot int
+	// Chance of a bullet fired from the formation being this kind, which increases with the wave
+	fireChancePercent func(wave int) int
+}
+
+var projectileKinds = map[projectileKind]projectileKindSettings{
+	plainBullet: {
+		glyph:         '.',
+		moveInterval:  1,
+		scoreWhenShot: scorePerBulletHit,
+	},
+	boltProjectile: {
+		glyph:             '!',
+		colour:            lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+		moveInterval:      1,
+		whenShot:          absorbs,
+		fireChancePercent: func(wave int) int { return min(2*(wave-2), 10) },
+	},
+	bombProjectile: {
+		glyph:             '8',
+		colour:            lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+		moveInterval:      3,
+		scoreWhenShot:     scorePerBombHit,
+		fireChancePercent: func(wave int) int { return min(2*(wave-3), 8) },
+	},
+	fragmentProjectile: {
+		glyph:         '\'',
+		colour:        lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+		moveInterval:  1,
+		scoreWhenShot: scorePerBulletHit,
+	},
+	zigZagProjectile: {
+		glyph:             'z',
+		moveInterval:      1,
+		scoreWhenShot:     scorePerBulletHit,
+		fireChancePercent: func(wave int) int { return min(3*(wave-1), 12) },
+	},
+	laserProjectile: {
+		glyph:             '|',
+		colour:            lipgloss.AdaptiveColor{Light: "5", Dark: "13"},
+		whenShot:          passesThrough,
+		fireChancePercent: func(wave int) int { return min(wave-4, 5) },
+	},
+}
+
+func (k projectileKind) settings() projectileKindSettings {
+	return projectileKinds[k]
+}
+
+// For lasers, the position is the top of the beam, which reaches down to the bottom of the screen
+type enemyBullet struct {
+	kind      projectileKind
+	position  vector2d
+	tick      int // Enemy bullet updates since it was fired
+	direction int // Horizontal direction, for zig-zag shots and fragments
+}
+
+func newEnemyBullet(kind projectileKind, position vector2d) enemyBullet {
+	return enemyBullet{kind: kind, position: position, direction: 1}
+}
+
+// Pick a random kind for a bullet fired from the formation, mostly plain bullets
+func pickProjectileKind(rng *rand.Rand, wave int) projectileKind {
+	roll := rng.IntN(100)
+	for _, kind := range []projectileKind{boltProjectile, bombProjectile, zigZagProjectile, laserProjectile} {
+		chance := max(kind.settings().fireChancePercent(wave), 0)
+		if roll < chance {
+			return kind
+		}
+		roll -= chance
+	}
+	return plainBullet
+}
+
+func (b enemyBullet) isTelegraphing() bool {
+	return b.kind == laserProjectile && b.tick < laserTelegraphTicks
+}
+
+// Whether the projectile can hurt whatever is at the given position
+func (b enemyBullet) hits(position vector2d) bool {
+	if b.kind == laserProjectile {
+		return !b.isTelegraphing() && position.x == b.position.x && position.y >= b.position.y
+	}
+	return position == b.position
+}
+
+// Advance the projectile by one update, returning what's left of it: nothing once it's left the screen or finished firing,
+// or several fragments for a bomb that's gone off
+// Also returns whether it left the screen, as opposed to a laser finishing firing
+func (b enemyBullet) update() (remaining []enemyBullet, leftScreen bool) {
+	b.tick++
+	switch b.kind {
+	case laserProjectile:
+		if b.tick >= laserTelegraphTicks+laserFireTicks {
+			return nil, false
+		}
+		return []enemyBullet{b}, false
+	case bombProjectile:
+		if b.position.y >= gameViewSize.y-bombFuseRows {
+			return b.explode(), false
+		}
+	}
+
+	if b.tick%b.kind.settings().moveInterval != 0 {
+		return []enemyBullet{b}, false
+	}
+	b.position.y++
+	switch b.kind {
+	case zigZagProjectile:
+		if !isPositionValid(vector2d{x: b.position.x + b.direction, y: b.position.y}) {
+			b.direction = -b.direction
+		}
+		b.position.x += b.direction
+		b.direction = -b.direction
+	case fragmentProjectile:
+		b.position.x += b.direction
+	}
+
+	if !isPositionValid(b.position) {
+		return nil, true
+	}
+	return []enemyBullet{b}, false
+}
+
+func (b enemyBullet) explode() []enemyBullet {
+	fragments := make([]enemyBullet, 0, fragmentDirectionCount)
+	for direction := -1; direction <= 1; direction++ {
+		fragments = append(fragments, enemyBullet{kind: fragmentProjectile, position: b.position, direction: direction})
+	}
+	return fragments
+}
+
+// Lasers hit the ship anywhere along its hitbox
+func (gv *gameView) isPlayerHitBy(b enemyBullet) bool {
+	if b.kind == laserProjectile {
+		position := vector2d{x: b.position.x, y: gv.playerPosition.y}
+		return b.hits(position) && gv.isPlayerAt(position)
+	}
+	return gv.isPlayerAt(b.position)
+}
+
+func (gv *gameView) isDroneHitBy(b enemyBullet) bool {
+	return gv.drone != nil && b.hits(gv.drone.position)
+}
+
+// Lasers are drawn as a column down to the bottom of the screen: dotted while telegraphing, then solid once firing
+// They're drawn even with the invisible bullets modifier, as the warning is the point
+func (gv *gameView) drawEnemyBullets(outputMatrix *[][]cell) {
+	for _, b := range gv.enemyBullets {
+		settings := b.kind.settings()
+		c := cell{r: settings.glyph, colour: settings.colour}
+		if b.kind == laserProjectile {
+			if b.isTelegraphing() {
+				c.r = ':'
+			}
+			for y := b.position.y; y < gameViewSize.y; y++ {
+				(*outputMatrix)[y][b.position.x] = c
+			}
+			continue
+		}
+
+		if gv.config.modifiers.has(invisibleEnemyBulletsModifier) && b.position.y < gv.playerPosition.y-invisibleEnemyBulletRange {
+			continue
+		}
+		(*outputMatrix)[b.position.y][b.position.x] = c
+	}
+}
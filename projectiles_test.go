@@ -0,0 +1,128 @@
+package main
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPickProjectileKind(t *testing.T) {
+	tests := []struct {
+		wave      int
+		wantKinds []projectileKind
+	}{
+		// Every special kind's chance is negative on the first wave, so is clamped to zero
+		{wave: 1, wantKinds: []projectileKind{plainBullet}},
+		{wave: 2, wantKinds: []projectileKind{plainBullet, zigZagProjectile}},
+		{wave: 4, wantKinds: []projectileKind{plainBullet, boltProjectile, bombProjectile, zigZagProjectile}},
+		{wave: 10, wantKinds: []projectileKind{plainBullet, boltProjectile, bombProjectile, zigZagProjectile, laserProjectile}},
+	}
+	for _, tt := range tests {
+		rng := rand.New(rand.NewPCG(1, 2))
+		picked := make(map[projectileKind]bool)
+		for i := 0; i < 1000; i++ {
+			picked[pickProjectileKind(rng, tt.wave)] = true
+		}
+		if len(picked) != len(tt.wantKinds) {
+			t.Errorf("wave %d: picked %v, want %v", tt.wave, picked, tt.wantKinds)
+		}
+		for _, kind := range tt.wantKinds {
+			if !picked[kind] {
+				t.Errorf("wave %d: never picked kind %d", tt.wave, kind)
+			}
+		}
+	}
+}
+
+// Update the projectile the given number of times, following the first projectile left each time
+func updateProjectile(t *testing.T, b enemyBullet, updates int) enemyBullet {
+	t.Helper()
+	for i := 0; i < updates; i++ {
+		remaining, leftScreen := b.update()
+		if len(remaining) == 0 || leftScreen {
+			t.Fatalf("projectile %+v gone after %d updates", b, i+1)
+		}
+		b = remaining[0]
+	}
+	return b
+}
+
+func TestPlainBulletLeavesScreen(t *testing.T) {
+	b := updateProjectile(t, newEnemyBullet(plainBullet, vector2d{x: 5, y: 0}), gameViewSize.y-1)
+	if want := (vector2d{x: 5, y: gameViewSize.y - 1}); b.position != want {
+		t.Fatalf("position = %v, want %v", b.position, want)
+	}
+
+	remaining, leftScreen := b.update()
+	if len(remaining) != 0 || !leftScreen {
+		t.Fatalf("update at the bottom = %v, %v; want nothing left, having left the screen", remaining, leftScreen)
+	}
+}
+
+func TestBombExplodesIntoFragments(t *testing.T) {
+	fuseY := gameViewSize.y - bombFuseRows
+	b := newEnemyBullet(bombProjectile, vector2d{x: 10, y: fuseY - 1})
+
+	// Bombs only move once every few updates
+	b = updateProjectile(t, b, bombProjectile.settings().moveInterval-1)
+	if b.position.y != fuseY-1 {
+		t.Fatalf("bomb moved early, to %v", b.position)
+	}
+	b = updateProjectile(t, b, 1)
+	if b.position.y != fuseY {
+		t.Fatalf("bomb at %v, want row %d", b.position, fuseY)
+	}
+
+	fragments, leftScreen := b.update()
+	if len(fragments) != fragmentDirectionCount || leftScreen {
+		t.Fatalf("explosion = %v, %v; want %d fragments", fragments, leftScreen, fragmentDirectionCount)
+	}
+	for i, f := range fragments {
+		if f.kind != fragmentProjectile || f.position != b.position {
+			t.Fatalf("fragment %d = %+v, want a fragment at %v", i, f, b.position)
+		}
+		f = updateProjectile(t, f, 1)
+		if want := (vector2d{x: b.position.x + i - 1, y: b.position.y + 1}); f.position != want {
+			t.Errorf("fragment %d moved to %v, want %v", i, f.position, want)
+		}
+	}
+}
+
+func TestZigZagProjectile(t *testing.T) {
+	b := newEnemyBullet(zigZagProjectile, vector2d{x: 5, y: 0})
+	for i, want := range []vector2d{{x: 6, y: 1}, {x: 5, y: 2}, {x: 6, y: 3}} {
+		b = updateProjectile(t, b, 1)
+		if b.position != want {
+			t.Fatalf("after %d updates, position = %v, want %v", i+1, b.position, want)
+		}
+	}
+
+	// Bounces off the edge of the screen instead of leaving it
+	b = updateProjectile(t, enemyBullet{kind: zigZagProjectile, position: vector2d{x: 0, y: 0}, direction: -1}, 1)
+	if want := (vector2d{x: 1, y: 1}); b.position != want {
+		t.Fatalf("position after bouncing = %v, want %v", b.position, want)
+	}
+}
+
+func TestLaserLifetime(t *testing.T) {
+	position := vector2d{x: 5, y: 3}
+	b := newEnemyBullet(laserProjectile, position)
+	playerPosition := vector2d{x: 5, y: gameViewSize.y - 1}
+
+	b = updateProjectile(t, b, laserTelegraphTicks-1)
+	if !b.isTelegraphing() || b.hits(playerPosition) {
+		t.Fatalf("laser should still be telegraphing after %d updates", b.tick)
+	}
+	b = updateProjectile(t, b, 1)
+	if b.isTelegraphing() || !b.hits(playerPosition) || b.hits(vector2d{x: 6, y: playerPosition.y}) {
+		t.Fatalf("laser should be firing down its column after %d updates", b.tick)
+	}
+	if b.position != position {
+		t.Fatalf("laser moved to %v", b.position)
+	}
+
+	b = updateProjectile(t, b, laserFireTicks-1)
+	remaining, leftScreen := b.update()
+	if len(remaining) != 0 || leftScreen {
+		t.Fatalf("update after firing = %v, %v; want nothing left, without having left the screen", remaining, leftScreen)
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const scrollTickInterval = 250 * time.Millisecond
+const scrollingLevelLength = 240 // Number of rows scrolled before the boss appears
+const maxWallWidth = 14
+const scrollingEnemySpacing = 3
+const scorePerTurretHit = 200
+const turretFireChancePercent = 15
+
+type timelineEventType int
+
+const (
+	enemyWaveEvent timelineEventType = iota
+	leftTurretEvent
+	rightTurretEvent
+)
+
+// Something that happens when the screen has scrolled a given number of rows
+type timelineEvent struct {
+	row        int
+	eventType  timelineEventType
+	enemyCount int // For enemy waves
+}
+
+// Turrets are placed on the ground at the edge of the walls, so scroll along with the terrain
+type turret struct {
+	levelRow int
+	x        int
+}
+
+var scrollingLevelTimeline = []timelineEvent{
+	{row: 10, eventType: enemyWaveEvent, enemyCount: 3},
+	{row: 25, eventType: enemyWaveEvent, enemyCount: 5},
+	{row: 35, eventType: leftTurretEvent},
+	{row: 45, eventType: rightTurretEvent},
+	{row: 55, eventType: enemyWaveEvent, enemyCount: 4},
+	{row: 70, eventType: leftTurretEvent},
+	{row: 72, eventType: rightTurretEvent},
+	{row: 85, eventType: enemyWaveEvent, enemyCount: 6},
+	{row: 100, eventType: enemyWaveEvent, enemyCount: 3},
+	{row: 105, eventType: enemyWaveEvent, enemyCount: 3},
+	{row: 120, eventType: leftTurretEvent},
+	{row: 130, eventType: rightTurretEvent},
+	{row: 140, eventType: enemyWaveEvent, enemyCount: 7},
+	{row: 160, eventType: leftTurretEvent},
+	{row: 165, eventType: enemyWaveEvent, enemyCount: 5},
+	{row: 170, eventType: rightTurretEvent},
+	{row: 185, eventType: enemyWaveEvent, enemyCount: 6},
+	{row: 200, eventType: leftTurretEvent},
+	{row: 200, eventType: rightTurretEvent},
+	{row: 210, eventType: enemyWaveEvent, enemyCount: 7},
+}
+
+// State for the scrolling stage; the level row of a screen row is counted up from the bottom of the screen
+type scrollingState struct {
+	scrollOffset int   // Number of rows scrolled so far (i.e. the level row at the bottom of the screen)
+	leftWalls    []int // Width of the left wall for each level row
+	rightWalls   []int
+	turrets      []turret
+}
+
+func (gv *gameView) newScrollingState() scrollingState {
+	rowCount := scrollingLevelLength + gameViewSize.y
+	ss := scrollingState{
+		leftWalls:  make([]int, rowCount),
+		rightWalls: make([]int, rowCount),
+	}
+
+	// Walls start off flat then wander randomly, changing width every few rows
+	// They flatten out again for the boss
+	left, right := 0, 0
+	for row := 0; row < rowCount; row++ {
+		if row > 20 && row < scrollingLevelLength-10 && row%3 == 0 {
+			left = min(max(left+gv.rng.IntN(3)-1, 1), maxWallWidth)
+			right = min(max(right+gv.rng.IntN(3)-1, 1), maxWallWidth)
+		} else if row >= scrollingLevelLength-10 {
+			left, right = max(left-1, 0), max(right-1, 0)
+		}
+		ss.leftWalls[row] = left
+		ss.rightWalls[row] = right
+	}
+	return ss
+}
+
+func (ss scrollingState) levelRow(y int) int {
+	return ss.scrollOffset + (gameViewSize.y - 1 - y)
+}
+
+func (ss scrollingState) screenY(levelRow int) int {
+	return gameViewSize.y - 1 - (levelRow - ss.scrollOffset)
+}
+
+func (ss scrollingState) isWall(position vector2d) bool {
+	row := ss.levelRow(position.y)
+	if row < 0 || row >= len(ss.leftWalls) {
+		return false
+	}
+	return position.x < ss.leftWalls[row] || position.x >= gameViewSize.x-ss.rightWalls[row]
+}
+
+// Centre of the gap between the walls on the given screen row
+func (ss scrollingState) gapCentre(y int) int {
+	row := ss.levelRow(y)
+	return (ss.leftWalls[row] + (gameViewSize.x - ss.rightWalls[row])) / 2
+}
+
+func (gv *gameView) updateScrolling() {
+	ss := &gv.scrolling
+
+	if gv.boss == nil {
+		ss.scrollOffset++
+		gv.triggerTimelineEvents()
+		gv.moveScrollingEnemies()
+
+		// Remove turrets that have scrolled off the bottom of the screen
+		updatedTurrets := make([]turret, 0, len(ss.turrets))
+		for _, t := range ss.turrets {
+			if ss.screenY(t.levelRow) < gameViewSize.y {
+				updatedTurrets = append(updatedTurrets, t)
+			}
+		}
+		ss.turrets = updatedTurrets
+
+		if ss.scrollOffset >= scrollingLevelLength {
+			gv.spawnBoss()
+		}
+	} else {
+		gv.updateBoss()
+	}
+
+	gv.createEnemyBullets()
+	gv.createTurretBullets()
+}
+
+func (gv *gameView) triggerTimelineEvents() {
+	ss := &gv.scrolling
+	for _, event := range scrollingLevelTimeline {
+		// Turrets are placed so they scroll onto the top of the screen at the time of the event
+		turretRow := event.row + gameViewSize.y - 1
+		switch {
+		case event.row != ss.scrollOffset:
+		case event.eventType == enemyWaveEvent:
+			gapCentre := ss.gapCentre(0)
+			firstX := gapCentre - ((event.enemyCount - 1) * scrollingEnemySpacing / 2)
+			for i := 0; i < event.enemyCount; i++ {
+				position := vector2d{x: firstX + (i * scrollingEnemySpacing), y: 0}
+				if isPositionValid(position) && !ss.isWall(position) {
+					gv.enemyPositions.set(position, newEnemy(basicEnemy))
+				}
+			}
+		case event.eventType == leftTurretEvent:
+			ss.turrets = append(ss.turrets, turret{levelRow: turretRow, x: ss.leftWalls[turretRow]})
+		case event.eventType == rightTurretEvent:
+			ss.turrets = append(ss.turrets, turret{levelRow: turretRow, x: gameViewSize.x - ss.rightWalls[turretRow] - 1})
+		}
+	}
+}
+
+// Enemies fly down the screen, weaving from side to side, and are removed once they leave the bottom
+func (gv *gameView) moveScrollingEnemies() {
+	updatedEnemyPositions := make(vector2dMap[enemy], len(gv.enemyPositions))
+	for y, xMap := range gv.enemyPositions {
+		for x, e := range xMap {
+			updatedPosition := vector2d{x: x, y: y + 1}
+			if (gv.scrolling.scrollOffset/4)%2 == 0 {
+				updatedPosition.x++
+			} else {
+				updatedPosition.x--
+			}
+			if gv.scrolling.isWall(updatedPosition) || !isPositionValid(vector2d{x: updatedPosition.x, y: 0}) {
+				updatedPosition.x = x
+			}
+
+			if !isPositionValid(updatedPosition) {
+				continue
+			}
+			updatedEnemyPositions.set(updatedPosition, e)
+		}
+	}
+	gv.enemyPositions = updatedEnemyPositions
+}
+
+func (gv *gameView) createTurretBullets() {
+	for _, t := range gv.scrolling.turrets {
+		position := vector2d{x: t.x, y: gv.scrolling.screenY(t.levelRow)}
+		if isPositionValid(position) && gv.canCollideWithPlayer(position) && gv.rng.IntN(100) < turretFireChancePercent {
+			gv.enemyBullets = append(gv.enemyBullets, newEnemyBullet(plainBullet, vector2d{x: position.x, y: position.y + 1}))
+		}
+	}
+}
+
+// Handle collisions specific to the scrolling stage: bullets hitting walls and turrets, and the player crashing into walls
+func (gv *gameView) handleScrollingCollisions() {
+	ss := &gv.scrolling
+
+	updatedPlayerBullets := make([]vector2d, 0, len(gv.playerBullets))
+	for _, bulletPosition := range gv.playerBullets {
+		if !gv.handlePlayerBulletHitScenery(bulletPosition) {
+			updatedPlayerBullets = append(updatedPlayerBullets, bulletPosition)
+		}
+	}
+	gv.playerBullets = updatedPlayerBullets
+
+	updatedEnemyBullets := make([]enemyBullet, 0, len(gv.enemyBullets))
+	for _, b := range gv.enemyBullets {
+		if !ss.isWall(b.position) {
+			updatedEnemyBullets = append(updatedEnemyBullets, b)
+		}
+	}
+	gv.enemyBullets = updatedEnemyBullets
+
+	if gv.drone != nil && ss.isWall(gv.drone.position) {
+		gv.destroyDrone()
+	}
+	if gv.isShipInWall(gv.playerPosition) && gv.isPlayerVulnerable() {
+		gv.loseLife()
+	}
+}
+
+// Returns true if the bullet hit a wall or turret (so should be removed)
+func (gv *gameView) handlePlayerBulletHitScenery(bulletPosition vector2d) bool {
+	ss := &gv.scrolling
+
+	if ss.isWall(bulletPosition) {
+		return true
+	}
+
+	for i, t := range ss.turrets {
+		// Also check the point above the turret, as turrets move down while bullets move up
+		turretY := ss.screenY(t.levelRow)
+		if t.x == bulletPosition.x && (turretY == bulletPosition.y || turretY-1 == bulletPosition.y) {
+			ss.turrets = append(ss.turrets[:i], ss.turrets[i+1:]...)
+			gv.addScore(scorePerTurretHit)
+			return true
+		}
+	}
+	return false
+}
+
+// Whether any part of the ship's hitbox would be inside a wall with the ship at the given position
+func (gv *gameView) isShipInWall(position vector2d) bool {
+	for dx := -gv.stats.hitboxWidth / 2; dx <= gv.stats.hitboxWidth/2; dx++ {
+		if gv.scrolling.isWall(vector2d{x: position.x + dx, y: position.y}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Move the player out of any walls after respawning
+func (gv *gameView) respawnInScrollingStage() {
+	if gv.isShipInWall(gv.playerPosition) {
+		gv.playerPosition.x = gv.scrolling.gapCentre(gv.playerPosition.y)
+	}
+}
+
+func (gv *gameView) getScrollingHUDString() string {
+	if gv.boss != nil {
+		return gv.getBossHUDString()
+	}
+	return fmt.Sprintf("Distance: %d/%d", gv.scrolling.scrollOffset, scrollingLevelLength)
+}
+
+func (gv *gameView) drawScenery(outputMatrix *[][]cell) {
+	ss := gv.scrolling
+	for y := range *outputMatrix {
+		for x := range (*outputMatrix)[y] {
+			if ss.isWall(vector2d{x: x, y: y}) {
+				(*outputMatrix)[y][x] = cell{r: '#'}
+			}
+		}
+	}
+
+	for _, t := range ss.turrets {
+		if position := (vector2d{x: t.x, y: ss.screenY(t.levelRow)}); isPositionValid(position) {
+			(*outputMatrix)[position.y][position.x] = cell{r: 'T'}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"retro-shooter-game/key_maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+const shipUnlocksFileName = "ships.json"
+
+type shipType int
+
+const (
+	falconShip shipType = iota
+	interceptorShip
+	gunshipShip
+	phantomShip
+)
+
+var ships = []shipType{falconShip, interceptorShip, gunshipShip, phantomShip}
+
+type shipSettings struct {
+	name             string
+	id               string // Saved in the unlocks file and with high scores, so shouldn't change
+	moveStepBonus    int    // Added to the configured move step
+	burstSize        int    // Number of bullets that can be fired before the cooldown starts
+	cooldownDuration time.Duration
+	bulletsPerShot   int
+	hitboxWidth      int // Must be odd, so the hitbox is centred on the ship
+	special          string
+	applySpecial     func(gv *gameView)
+	// Ships without an unlock condition are always available
+	unlockDescription string
+	isUnlockedBy      func(gv *gameView) bool
+}
+
+var shipSettingsMap = map[shipType]shipSettings{
+	falconShip: {
+		name:             "Falcon",
+		id:               "falcon",
+		burstSize:        playerBulletCooldownMaxCount,
+		cooldownDuration: playerBulletCooldownDuration,
+		bulletsPerShot:   1,
+		hitboxWidth:      1,
+		special:          "Shield recharges twice as fast",
+		applySpecial:     func(gv *gameView) { gv.shield.cooldown /= 2 },
+	},
+	interceptorShip: {
+		name:              "Interceptor",
+		id:                "interceptor",
+		moveStepBonus:     1,
+		burstSize:         3,
+		cooldownDuration:  playerBulletCooldownDuration,
+		bulletsPerShot:    1,
+		hitboxWidth:       1,
+		special:           "Dash recharges twice as fast",
+		applySpecial:      func(gv *gameView) { gv.dash.cooldown /= 2 },
+		unlockDescription: "Score 5000 points in one game",
+		isUnlockedBy:      func(gv *gameView) bool { return gv.score >= 5000 },
+	},
+	gunshipShip: {
+		name:              "Gunship",
+		id:                "gunship",
+		burstSize:         playerBulletCooldownMaxCount,
+		cooldownDuration:  playerBulletCooldownDuration * 3 / 2,
+		bulletsPerShot:    2,
+		hitboxWidth:       3,
+		special:           "Starts with two extra smart bombs",
+		applySpecial:      func(gv *gameView) { gv.smartBombs += 2 },
+		unlockDescription: "Reach wave 5",
+		isUnlockedBy:      func(gv *gameView) bool { return gv.wave >= 5 },
+	},
+	phantomShip: {
+		name:              "Phantom",
+		id:                "phantom",
+		burstSize:         playerBulletCooldownMaxCount + 2,
+		cooldownDuration:  playerBulletCooldownDuration * 3 / 4,
+		bulletsPerShot:    1,
+		hitboxWidth:       1,
+		special:           "Piercing shots",
+		applySpecial:      func(gv *gameView) { gv.stats.piercingShots = true },
+		unlockDescription: "Win a game on one credit",
+		isUnlockedBy:      func(gv *gameView) bool { return gv.status == gameWon && gv.creditsUsed() == 1 },
+	},
+}
+
+func (s shipType) settings() shipSettings {
+	return shipSettingsMap[s]
+}
+
+func (s shipType) String() string {
+	return s.settings().name
+}
+
+func shipByID(id string) (shipType, bool) {
+	for _, s := range ships {
+		if s.settings().id == id {
+			return s, true
+		}
+	}
+	return falconShip, false
+}
+
+// IDs of the ships unlocked so far
+type shipUnlocks struct {
+	Unlocked []string `json:"unlocked"`
+}
+
+func loadShipUnlocks() (shipUnlocks, error) {
+	var unlocks shipUnlocks
+	err := readDataFile(shipUnlocksFileName, &unlocks)
+	return unlocks, err
+}
+
+func (su shipUnlocks) isUnlocked(s shipType) bool {
+	return s.settings().isUnlockedBy == nil || slices.Contains(su.Unlocked, s.settings().id)
+}
+
+// Unlock any ships whose conditions were met by the game that just ended, returning the newly unlocked ships
+func (gv *gameView) unlockShips() ([]shipType, error) {
+	unlocks, err := loadShipUnlocks()
+	if err != nil {
+		return nil, err
+	}
+
+	var newlyUnlocked []shipType
+	for _, s := range ships {
+		if !unlocks.isUnlocked(s) && s.settings().isUnlockedBy(gv) {
+			unlocks.Unlocked = append(unlocks.Unlocked, s.settings().id)
+			newlyUnlocked = append(newlyUnlocked, s)
+		}
+	}
+	if len(newlyUnlocked) == 0 {
+		return nil, nil
+	}
+	return newlyUnlocked, writeDataFile(shipUnlocksFileName, unlocks)
+}
+
+// Whether the player is hit by something at the given position, taking the width of the ship's hitbox into account
+func (gv *gameView) isPlayerAt(position vector2d) bool {
+	return position.y == gv.playerPosition.y && abs(position.x-gv.playerPosition.x) <= gv.stats.hitboxWidth/2
+}
+
+// Chosen before each game, apart from the daily challenge where everyone flies the same ship
+type shipSelectView struct {
+	config            gameConfig
+	unlocks           shipUnlocks
+	selectedShipIndex int
+	err               error
+}
+
+func newShipSelectView(config gameConfig) shipSelectView {
+	unlocks, err := loadShipUnlocks()
+	return shipSelectView{
+		config:            config,
+		unlocks:           unlocks,
+		selectedShipIndex: slices.Index(ships, config.ship),
+		err:               err,
+	}
+}
+
+func (ssv shipSelectView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key_maps.ShipSelectKeys.Up):
+			ssv.selectedShipIndex = (ssv.selectedShipIndex - 1 + len(ships)) % len(ships)
+			m.view = ssv
+		case key.Matches(msg, key_maps.ShipSelectKeys.Down):
+			ssv.selectedShipIndex = (ssv.selectedShipIndex + 1) % len(ships)
+			m.view = ssv
+		case key.Matches(msg, key_maps.ShipSelectKeys.Start) && ssv.unlocks.isUnlocked(ships[ssv.selectedShipIndex]):
+			config := ssv.config
+			config.ship = ships[ssv.selectedShipIndex]
+			// Remember the choice for the next game
+			m.config.ship = config.ship
+			return startGame(m, config)
+		case key.Matches(msg, key_maps.ShipSelectKeys.Back):
+			if ssv.config.campaignStages != nil {
+				m.view = stageIntroView{config: ssv.config}
+			} else {
+				m.view = newTitleView()
+			}
+		}
+	}
+	return m, nil
+}
+
+func (ssv shipSelectView) draw(m model) string {
+	var sb strings.Builder
+	if ssv.err != nil {
+		sb.WriteString(fmt.Sprintf("Couldn't load unlocked ships: %v\n\n", ssv.err))
+	}
+
+	for i, s := range ships {
+		line := s.String()
+		if !ssv.unlocks.isUnlocked(s) {
+			line = fmt.Sprintf("??? (locked: %s)", s.settings().unlockDescription)
+		}
+		sb.WriteString(drawMenuItem(line, i == ssv.selectedShipIndex, !ssv.unlocks.isUnlocked(s)))
+		sb.WriteString("\n")
+	}
+
+	selected := ships[ssv.selectedShipIndex]
+	if ssv.unlocks.isUnlocked(selected) {
+		settings := selected.settings()
+		sb.WriteString(secondaryTextStyle.Render(fmt.Sprintf(
+			"Speed: %d; Burst: %d; Cooldown: %s; Bullets per shot: %d; Hitbox: %d\nSpecial: %s",
+			m.config.playerMoveStep+settings.moveStepBonus, settings.burstSize, settings.cooldownDuration, settings.bulletsPerShot, settings.hitboxWidth, settings.special,
+		)))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		lipgloss.NewStyle().PaddingBottom(1).Render("Choose your ship"),
+		lipgloss.NewStyle().PaddingBottom(1).Render(sb.String()),
+		m.help.View(key_maps.ShipSelectKeys),
+	)
+}
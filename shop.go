@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const scorePerCoin = 250
+
+// Stats of the player's ship for the current run, which can be upgraded in the shop
+type playerStats struct {
+	moveStep                int // Number of cells the player moves left/right per key press
+	bulletCooldownMaxCount  int // Number of bullets that can be fired before the cooldown starts
+	bulletCooldownDuration  time.Duration
+	bulletsPerShot          int
+	piercingShots           bool // Whether bullets carry on through enemies after damaging them
+	hitboxWidth             int
+	enemyBulletMoveInterval int // Enemy bullets move once every this many bullet ticks
+}
+
+// Starting stats for the chosen ship
+func newPlayerStats(config gameConfig) playerStats {
+	ship := config.ship.settings()
+	return playerStats{
+		moveStep:                config.playerMoveStep + ship.moveStepBonus,
+		bulletCooldownMaxCount:  ship.burstSize,
+		bulletCooldownDuration:  ship.cooldownDuration,
+		bulletsPerShot:          ship.bulletsPerShot,
+		hitboxWidth:             ship.hitboxWidth,
+		enemyBulletMoveInterval: 1,
+	}
+}
+
+type upgrade struct {
+	name     string
+	cost     int
+	maxCount int // Maximum number of times the upgrade can be bought per run
+	// Returns false if the upgrade can't be applied right now (e.g. already at the maximum number of lives)
+	apply func(gv *gameView) bool
+}
+
+var upgrades = []upgrade{
+	{
+		name:     "Faster movement",
+		cost:     15,
+		maxCount: 2,
+		apply: func(gv *gameView) bool {
+			gv.stats.moveStep++
+			return true
+		},
+	},
+	{
+		name:     "Larger burst before cooldown",
+		cost:     10,
+		maxCount: 3,
+		apply: func(gv *gameView) bool {
+			gv.stats.bulletCooldownMaxCount += 2
+			return true
+		},
+	},
+	{
+		name:     "Extra bullet per shot",
+		cost:     30,
+		maxCount: 2,
+		apply: func(gv *gameView) bool {
+			gv.stats.bulletsPerShot++
+			return true
+		},
+	},
+	{
+		name:     "Piercing shots",
+		cost:     40,
+		maxCount: 1,
+		apply: func(gv *gameView) bool {
+			gv.stats.piercingShots = true
+			return true
+		},
+	},
+	{
+		name:     "Shield charge",
+		cost:     8,
+		maxCount: 5,
+		apply: func(gv *gameView) bool {
+			gv.ramShieldCharges++
+			return true
+		},
+	},
+	{
+		name:     "Extra life",
+		cost:     25,
+		maxCount: 3,
+		apply: func(gv *gameView) bool {
+			if gv.lives.isFull() {
+				return false
+			}
+			gv.lives.remaining++
+			return true
+		},
+	},
+}
+
+type shop struct {
+	selectedItemIndex int
+	purchaseCounts    []int // Number of times each upgrade has been bought this run
+	message           string
+}
+
+func newShop() shop {
+	return shop{purchaseCounts: make([]int, len(upgrades))}
+}
+
+// Coins are earned from score, so are awarded whenever score is added
+func (gv *gameView) updateCoins(previousScore int) {
+	gv.coins += (gv.score / scorePerCoin) - (previousScore / scorePerCoin)
+}
+
+func (gv *gameView) openShop() {
+	gv.status = shopping
+	gv.shop.message = ""
+	gv.clock.pause()
+}
+
+func (gv *gameView) moveShopSelection(offset int) {
+	gv.shop.selectedItemIndex = (gv.shop.selectedItemIndex + offset + len(upgrades)) % len(upgrades)
+}
+
+func (gv *gameView) buySelectedUpgrade() {
+	index := gv.shop.selectedItemIndex
+	u := upgrades[index]
+	switch {
+	case gv.shop.purchaseCounts[index] >= u.maxCount:
+		gv.shop.message = "Sold out!"
+	case gv.coins < u.cost:
+		gv.shop.message = "Not enough coins!"
+	case !u.apply(gv):
+		gv.shop.message = "Can't buy that right now!"
+	default:
+		gv.coins -= u.cost
+		gv.shop.purchaseCounts[index]++
+		gv.shop.message = fmt.Sprintf("Bought %s!", strings.ToLower(u.name))
+	}
+}
+
+func (gv *gameView) getShopString() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Wave %d cleared! Shop (coins: %d)\n", gv.wave, gv.coins))
+	for i, u := range upgrades {
+		line := fmt.Sprintf("%-30s %3d coins  (%d/%d)", u.name, u.cost, gv.shop.purchaseCounts[i], u.maxCount)
+		sb.WriteString(drawMenuItem(line, i == gv.shop.selectedItemIndex, gv.shop.purchaseCounts[i] >= u.maxCount || gv.coins < u.cost))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(gv.shop.message)
+	return sb.String()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const minEnemyTickInterval = 30 * time.Millisecond
+const heartbeatLength = 4 // Number of beats in the heartbeat indicator, like the classic four-note march
+
+// How much harder the game is, which controls how quickly the formation speeds up
+type difficulty int
+
+const (
+	normalDifficulty difficulty = iota
+	easyDifficulty
+	hardDifficulty
+)
+
+var difficultyNames = map[difficulty]string{
+	easyDifficulty:   "easy",
+	normalDifficulty: "normal",
+	hardDifficulty:   "hard",
+}
+
+func (d difficulty) String() string {
+	return difficultyNames[d]
+}
+
+// Implements `flag.Value` so the difficulty can be set from the command line
+func (d *difficulty) Set(s string) error {
+	for value, name := range difficultyNames {
+		if name == s {
+			*d = value
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown difficulty %q", s)
+}
+
+// How the formation's step interval shrinks as it loses enemies and descends
+// Each factor is what the interval is multiplied by at the extreme, with the factor scaling linearly up to it
+type tempoCurve struct {
+	lastEnemyFactor float64 // Once only one enemy is left
+	bottomFactor    float64 // Once the formation has reached the bottom
+}
+
+var tempoCurves = map[difficulty]tempoCurve{
+	easyDifficulty:   {lastEnemyFactor: 0.5, bottomFactor: 0.9},
+	normalDifficulty: {lastEnemyFactor: 0.25, bottomFactor: 0.75},
+	hardDifficulty:   {lastEnemyFactor: 0.1, bottomFactor: 0.6},
+}
+
+func (d difficulty) tempoCurve() tempoCurve {
+	return tempoCurves[d]
+}
+
+// Multiplier for the formation's step interval given the fraction of enemies destroyed and how far it has descended
+func (tc tempoCurve) factor(destroyedFraction float64, descentFraction float64) float64 {
+	populationFactor := 1 - (1-tc.lastEnemyFactor)*destroyedFraction
+	descentFactor := 1 - (1-tc.bottomFactor)*descentFraction
+	return populationFactor * descentFactor
+}
+
+// Whether the formation's speed follows the tempo curve; other stages and the boss move at a fixed speed
+func (gv *gameView) hasTempo() bool {
+	return gv.stage == formationStage && gv.boss == nil
+}
+
+// Multiplier for the formation's step interval at the moment
+func (gv *gameView) tempoFactor() float64 {
+	if !gv.hasTempo() {
+		return 1
+	}
+
+	// Measured so the last enemy left is at the fastest tempo
+	initialCount := gv.enemyRowCount * gv.enemyColumnCount
+	destroyedFraction := 0.0
+	if initialCount > 1 {
+		destroyedFraction = float64(initialCount-max(gv.enemyPositions.count(), 1)) / float64(initialCount-1)
+	}
+
+	// Measured from the formation's starting bottom row to the second to last row, where it lands
+	// Uses the lowest remaining enemy, as whole rows can be destroyed
+	startY := gv.enemyRowCount - 1
+	descentFraction := min(max(float64(gv.enemyPositions.maxY()-startY)/float64(gameViewSize.y-2-startY), 0), 1)
+
+	return gv.config.difficulty.tempoCurve().factor(destroyedFraction, descentFraction)
+}
+
+// Current speed of the formation relative to its starting speed, e.g. 2 when it's moving twice as fast
+func (gv *gameView) tempo() float64 {
+	return 1 / gv.tempoFactor()
+}
+
+func (gv *gameView) getTempoHUDString() string {
+	var sb strings.Builder
+	for i := 0; i < heartbeatLength; i++ {
+		if i == gv.heartbeat%heartbeatLength {
+			sb.WriteRune('♥')
+		} else {
+			sb.WriteRune('·')
+		}
+	}
+	return fmt.Sprintf("Tempo: x%.1f %s", gv.tempo(), sb.String())
+}
+
+// Internal state that's useful when tuning the game, shown when the debug overlay is toggled on
+func (gv *gameView) getDebugOverlayString() string {
+	lines := []string{
+		fmt.Sprintf("Difficulty: %s; Seed: %d", gv.config.difficulty, gv.config.seed),
+		fmt.Sprintf("Enemy tick: %s; Tempo factor: %.3f; Beat: %d", gv.enemyTickInterval(), gv.tempoFactor(), gv.heartbeat),
+		fmt.Sprintf("Enemies: %d/%d; Descent: %d; Divers: %d; Hazards: %d", gv.enemyPositions.count(), gv.enemyRowCount*gv.enemyColumnCount, gv.enemyYOffset, len(gv.divers), len(gv.hazards)),
+	}
+	return secondaryTextStyle.Render(strings.Join(lines, "\n"))
+}
@@ -1,25 +1,68 @@
 package main
 
 import (
+	"fmt"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"retro-shooter-game/key_maps"
+	"strings"
+	"time"
 )
 
-type titleView struct{}
+type titleView struct {
+	selectedItemIndex int // Index into `gameModes`, or one of the extra items after the modes
+	err               error
+}
+
+// Menu items after the game modes
+const (
+	dailyChallengeMenuItem = iota
+	campaignMenuItem
+	extraMenuItemCount
+)
 
 func newTitleView() titleView {
 	return titleView{}
 }
 
-func (titleView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
+func (tv titleView) menuItemCount() int {
+	return len(gameModes) + extraMenuItemCount
+}
+
+func (tv titleView) isDailyChallengeSelected() bool {
+	return tv.selectedItemIndex == len(gameModes)+dailyChallengeMenuItem
+}
+
+func (tv titleView) isCampaignSelected() bool {
+	return tv.selectedItemIndex == len(gameModes)+campaignMenuItem
+}
+
+func (tv titleView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, key_maps.TitleViewKeys.Up):
+			tv.selectedItemIndex = (tv.selectedItemIndex - 1 + tv.menuItemCount()) % tv.menuItemCount()
+			m.view = tv
+		case key.Matches(msg, key_maps.TitleViewKeys.Down):
+			tv.selectedItemIndex = (tv.selectedItemIndex + 1) % tv.menuItemCount()
+			m.view = tv
 		case key.Matches(msg, key_maps.TitleViewKeys.Start):
-			m.view = newGameView()
-			return m, tea.Batch(enemyTickCmd(), bulletTickCmd())
+			if tv.isCampaignSelected() {
+				m.view = newCampaignSelectView()
+				return m, nil
+			}
+
+			config := m.config
+			if tv.isDailyChallengeSelected() {
+				config.dailyChallenge = true
+				return startGame(m, config)
+			}
+			config.mode = gameModes[tv.selectedItemIndex]
+			m.view = newShipSelectView(config)
+		case key.Matches(msg, key_maps.TitleViewKeys.Modifiers):
+			m.view = modifiersView{titleView: tv}
 		case key.Matches(msg, key_maps.TitleViewKeys.Quit):
 			return m, tea.Quit
 		}
@@ -27,7 +70,7 @@ func (titleView) update(msg tea.Msg, m model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (titleView) draw(m model) string {
+func (tv titleView) draw(m model) string {
 	titleStringPart1 := "  ____      _               ____  _                 _            \n |  _ \\ ___| |_ _ __ ___   / ___|| |__   ___   ___ | |_ ___ _ __ \n | |_) / _ \\ __| '__/ _ \\  \\___ \\| '_ \\ / _ \\ / _ \\| __/ _ \\ '__|\n |  _ <  __/ |_| | | (_) |  ___) | | | | (_) | (_) | ||  __/ |   \n |_| \\_\\___|\\__|_|  \\___/  |____/|_| |_|\\___/ \\___/ \\__\\___|_|   "
 	titleStringPart2 := "   ____                      \n  / ___| __ _ _ __ ___   ___ \n | |  _ / _` | '_ ` _ \\ / _ \\\n | |_| | (_| | | | | | |  __/\n  \\____|\\__,_|_| |_| |_|\\___|"
 	titleStringPart2WithVersion := lipgloss.JoinHorizontal(
@@ -36,14 +79,43 @@ func (titleView) draw(m model) string {
 		secondaryTextStyle.Render(version),
 	)
 	titleString := lipgloss.JoinVertical(lipgloss.Center, titleStringPart1, titleStringPart2WithVersion)
-	pressToStartString := "Press enter key to start..."
+	pressToStartString := "Select a mode and press enter key to start..."
+	if tv.err != nil {
+		pressToStartString = fmt.Sprintf("Couldn't start daily challenge: %v", tv.err)
+	}
 	helpView := m.help.View(key_maps.TitleViewKeys)
 
 	viewString := lipgloss.JoinVertical(
 		lipgloss.Center,
 		lipgloss.NewStyle().PaddingBottom(2).Render(titleString),
 		lipgloss.NewStyle().PaddingBottom(1).Render(pressToStartString),
+		lipgloss.NewStyle().PaddingBottom(1).Render(tv.drawMenu(m)),
 		helpView,
 	)
 	return lipgloss.NewStyle().Padding(1, 0).Render(viewString)
 }
+
+func (tv titleView) drawMenu(m model) string {
+	itemNames := make([]string, 0, tv.menuItemCount())
+	for _, mode := range gameModes {
+		itemNames = append(itemNames, mode.String())
+	}
+	itemNames = append(itemNames, "Daily Challenge", "Campaign")
+
+	var sb strings.Builder
+	for i, itemName := range itemNames {
+		sb.WriteString(drawMenuItem(itemName, i == tv.selectedItemIndex, false))
+		sb.WriteString("\n")
+	}
+
+	var description string
+	if tv.isCampaignSelected() {
+		description = "Fight through a series of stages, unlocking each in turn"
+	} else if tv.isDailyChallengeSelected() {
+		description = fmt.Sprintf("Today's run: %s (one scored attempt per day)", describeDailyChallenge(dailyChallengeConfig(m.config, time.Now())))
+	} else {
+		description = fmt.Sprintf("%s\nModifiers: %s", gameModes[tv.selectedItemIndex].settings().description, m.config.modifiers)
+	}
+	sb.WriteString(secondaryTextStyle.Render(description))
+	return sb.String()
+}
@@ -7,3 +7,14 @@ type view interface {
 	update(msg tea.Msg, m model) (tea.Model, tea.Cmd)
 	draw(m model) string
 }
+
+// A line of a menu, marked and highlighted when selected, or greyed out when it can't be chosen
+func drawMenuItem(line string, selected bool, disabled bool) string {
+	switch {
+	case selected:
+		return selectedMenuItemStyle.Render("> " + line)
+	case disabled:
+		return secondaryTextStyle.Render("  " + line)
+	}
+	return "  " + line
+}
@@ -0,0 +1,87 @@
+package main
+
+const dronePickupDropChancePercent = 2 // Chance of a destroyed enemy dropping a drone pickup, if the player has no drone
+const droneLagTicks = 3                // The drone follows where the ship was this many bullet ticks ago
+const droneFireInterval = 8            // The drone fires once every this many bullet ticks
+
+// Wingman drone that flies beside the ship and fires its own bullets, until it's hit
+type drone struct {
+	position vector2d
+	trail    []vector2d // Recent positions of the ship, oldest first
+	tick     int
+}
+
+func (gv *gameView) dropDronePickup(position vector2d) {
+	if gv.drone != nil || len(gv.dronePickups) > 0 {
+		return
+	}
+	if gv.rng.IntN(100) < dronePickupDropChancePercent {
+		gv.dronePickups = append(gv.dronePickups, position)
+	}
+}
+
+// The player collects drone pickups by touching them, launching a drone beside the ship
+func (gv *gameView) handleDronePickupCollisions() {
+	updatedPickups := make([]vector2d, 0, len(gv.dronePickups))
+	for _, position := range gv.dronePickups {
+		if gv.isPlayerAt(position) {
+			if gv.drone == nil {
+				gv.drone = &drone{position: gv.dronePosition(gv.playerPosition), trail: []vector2d{gv.playerPosition}}
+			}
+		} else {
+			updatedPickups = append(updatedPickups, position)
+		}
+	}
+	gv.dronePickups = updatedPickups
+}
+
+// Where the drone flies when the ship is at the given position: to the left of it, or the right if that's off screen
+func (gv *gameView) dronePosition(shipPosition vector2d) vector2d {
+	offset := gv.stats.hitboxWidth/2 + 2 // Outside the shield
+	position := vector2d{x: shipPosition.x - offset, y: shipPosition.y}
+	if !isPositionValid(position) {
+		position.x = shipPosition.x + offset
+	}
+	return position
+}
+
+// Move the drone to follow the ship with a slight lag, and fire every so often
+func (gv *gameView) updateDrone() {
+	d := gv.drone
+	if d == nil {
+		return
+	}
+
+	d.trail = append(d.trail, gv.playerPosition)
+	if len(d.trail) > droneLagTicks {
+		d.trail = d.trail[1:]
+	}
+	d.position = gv.dronePosition(d.trail[0])
+
+	d.tick++
+	if d.tick >= droneFireInterval && !gv.config.modifiers.has(pacifistModifier) {
+		d.tick = 0
+		gv.playerBullets = append(gv.playerBullets, vector2d{x: d.position.x, y: d.position.y - 1})
+	}
+}
+
+func (gv *gameView) isDroneAt(position vector2d) bool {
+	return gv.drone != nil && gv.drone.position == position
+}
+
+// The drone absorbs one hit, then is destroyed
+func (gv *gameView) destroyDrone() {
+	gv.drone = nil
+}
+
+func (gv *gameView) drawDrone(outputMatrix *[][]cell) {
+	if gv.drone != nil && isPositionValid(gv.drone.position) {
+		(*outputMatrix)[gv.drone.position.y][gv.drone.position.x] = cell{r: '^', colour: accentColor}
+	}
+}
+
+func (gv *gameView) drawDronePickups(outputMatrix *[][]cell) {
+	for _, position := range gv.dronePickups {
+		(*outputMatrix)[position.y][position.x] = cell{r: 'D', colour: accentColor}
+	}
+}